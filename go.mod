@@ -2,9 +2,13 @@ module github.com/IsaqueGeraldo/efi
 
 go 1.23.1
 
-require github.com/go-ozzo/ozzo-validation v3.6.0+incompatible
+require (
+	github.com/go-ozzo/ozzo-validation v3.6.0+incompatible
+	software.sslmate.com/src/go-pkcs12 v0.7.3
+)
 
 require (
 	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2 // indirect
 	github.com/stretchr/testify v1.9.0 // indirect
+	golang.org/x/crypto v0.28.0 // indirect
 )
@@ -0,0 +1,84 @@
+package pix
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// loadCertificate returns the account certificate from the credentials,
+// preferring raw .p12 bytes, then a .p12 file, then the PEM CA and Key pair
+func loadCertificate(c Credentials) (tls.Certificate, error) {
+	switch {
+	case len(c.Certificate) > 0:
+		return decodeP12(c.Certificate, c.Password)
+
+	case c.P12 != "":
+		if err := fileExists(c.P12); err != nil {
+			return tls.Certificate{}, err
+		}
+
+		data, err := os.ReadFile(c.P12)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+
+		return decodeP12(data, c.Password)
+
+	default:
+		if err := fileExists(c.CA); err != nil {
+			return tls.Certificate{}, err
+		}
+
+		if err := fileExists(c.Key); err != nil {
+			return tls.Certificate{}, err
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.CA, c.Key)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("failed to load certificates: %v", err)
+		}
+
+		return cert, nil
+	}
+}
+
+// decodeP12 converts a PKCS#12 bundle into a TLS certificate
+func decodeP12(data []byte, password string) (tls.Certificate, error) {
+	key, cert, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to decode p12 certificate: %v", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return tls.Certificate{}, errors.New("p12 certificate has an unsupported private key")
+	}
+
+	// The bundle may carry the issuer chain in any order, so pick as the
+	// leaf the certificate that matches the private key.
+	certs := append([]*x509.Certificate{cert}, chain...)
+	for i, leaf := range certs {
+		public, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !public.Equal(signer.Public()) {
+			continue
+		}
+
+		tlsCert := tls.Certificate{PrivateKey: key, Leaf: leaf}
+		tlsCert.Certificate = append(tlsCert.Certificate, leaf.Raw)
+		for j, issuer := range certs {
+			if j != i {
+				tlsCert.Certificate = append(tlsCert.Certificate, issuer.Raw)
+			}
+		}
+
+		return tlsCert, nil
+	}
+
+	return tls.Certificate{}, errors.New("p12 certificate does not match its private key")
+}
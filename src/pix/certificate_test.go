@@ -0,0 +1,70 @@
+package pix
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// newTestCertificate returns a self-signed certificate and its key
+func newTestCertificate(t *testing.T, name string) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, cert
+}
+
+func TestDecodeP12Modern(t *testing.T) {
+	key, leaf := newTestCertificate(t, "leaf")
+	_, issuer := newTestCertificate(t, "issuer")
+
+	// Modern matches OpenSSL 3 defaults: PBES2 with AES-256 and a SHA-256 MAC.
+	// The issuer goes first so the leaf has to be found by its key.
+	data, err := pkcs12.Modern.Encode(key, issuer, []*x509.Certificate{leaf}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := decodeP12(data, "secret")
+	if err != nil {
+		t.Fatalf("decodeP12: %v", err)
+	}
+	if cert.Leaf.Subject.CommonName != "leaf" {
+		t.Errorf("leaf = %q, want %q", cert.Leaf.Subject.CommonName, "leaf")
+	}
+	if len(cert.Certificate) != 2 {
+		t.Errorf("chain has %d certificates, want 2", len(cert.Certificate))
+	}
+
+	if _, err := decodeP12(data, "wrong"); err == nil {
+		t.Error("decodeP12 with a wrong password succeeded")
+	}
+}
@@ -1,14 +1,23 @@
 package pix
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
-var Client *Credentials
-var EFI_BASE_URL string
+// DefaultClient is the client used by the package-level entry points
+var DefaultClient *Client
 
 // Credentials holds the authentication information for the client
 type Credentials struct {
@@ -18,6 +27,31 @@ type Credentials struct {
 	Sandbox      bool
 	CA           string
 	Key          string
+
+	// P12 is the path to the .p12 certificate issued by Efí, used instead
+	// of CA and Key. Certificate holds the same bundle as raw bytes.
+	P12         string
+	Certificate []byte
+	Password    string
+
+	// KeySelector chooses the key for charges created without one.
+	// When it is nil, the account must have a single key.
+	KeySelector KeySelector
+
+	// Retry configures how transient failures are retried.
+	Retry RetryPolicy
+
+	// Transport replaces the mTLS transport built from CA and Key.
+	// It must present the account certificate on its own.
+	Transport http.RoundTripper
+}
+
+// Client holds everything needed to talk to a single Efí account
+type Client struct {
+	credentials Credentials
+	baseURL     string
+	tokens      tokenCache
+	http        *http.Client
 }
 
 // fileExists checks if the specified file exists
@@ -28,34 +62,211 @@ func fileExists(fileName string) error {
 	return nil
 }
 
-// NewClient initializes a new client with the provided credentials
-func (c Credentials) NewClient() error {
-	err := validation.ValidateStruct(&c,
+// NewClient validates the credentials and returns a client bound to them
+func NewClient(c Credentials) (*Client, error) {
+	rules := []*validation.FieldRules{
 		validation.Field(&c.ClientID, validation.Required),
 		validation.Field(&c.ClientSecret, validation.Required),
 		validation.Field(&c.Timeout, validation.Required),
-		validation.Field(&c.CA, validation.Required),
-		validation.Field(&c.Key, validation.Required),
-	)
+	}
+
+	// The PEM files are only needed when we build the transport from them.
+	if c.Transport == nil && c.P12 == "" && len(c.Certificate) == 0 {
+		rules = append(rules,
+			validation.Field(&c.CA, validation.Required),
+			validation.Field(&c.Key, validation.Required),
+		)
+	}
+
+	if err := validation.ValidateStruct(&c, rules...); err != nil {
+		return nil, err
+	}
+
+	transport := c.Transport
+	if transport == nil {
+		cert, err := loadCertificate(c)
+		if err != nil {
+			return nil, err
+		}
+		transport = newTransport(cert)
+	}
+
+	// Set the base URL based on the environment (production or sandbox)
+	baseURL := EFI_PRODUCTION_URL
+	if c.Sandbox {
+		baseURL = EFI_STAGING_URL
+	}
+
+	return &Client{
+		credentials: c,
+		baseURL:     baseURL,
+		http: &http.Client{
+			Timeout:   time.Second * time.Duration(c.Timeout),
+			Transport: transport,
+		},
+	}, nil
+}
+
+// NewClient initializes the default client with the provided credentials
+func (c Credentials) NewClient() error {
+	client, err := NewClient(c)
 	if err != nil {
 		return err
 	}
 
-	if err := fileExists(c.CA); err != nil {
-		return err
+	DefaultClient = client
+
+	return nil
+}
+
+// defaultClient returns the default client or an error if it was not initialized
+func defaultClient() (*Client, error) {
+	if DefaultClient == nil {
+		return nil, errors.New("client not defined")
+	}
+	return DefaultClient, nil
+}
+
+// newTransport returns a pooled transport presenting the account certificate
+// that keeps connections to the API alive between calls
+func newTransport(cert tls.Certificate) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 16
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	return transport
+}
+
+// newRequest builds an authenticated JSON request for the given API path
+func (c *Client) newRequest(ctx context.Context, method string, body interface{}, path ...string) (*http.Request, error) {
+	// Obtain an OAuth token for authentication.
+	token := c.OAuth(ctx)
+	if token.Error != nil {
+		return nil, token.Error
+	}
+
+	// Construct the request path.
+	endpoint, err := url.JoinPath(c.baseURL, path...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal the body to JSON format, if any.
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewReader(data)
 	}
 
-	if err := fileExists(c.Key); err != nil {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the appropriate headers for the request.
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("authorization", token.authorization())
+
+	return req, nil
+}
+
+// do executes the request, decodes the response into out and checks the status
+func (c *Client) do(req *http.Request, out interface{}, status int) error {
+	res, body, err := c.authorized(req)
+	if err != nil {
 		return err
 	}
 
-	// Set the base URL based on the environment (production or sandbox)
-	EFI_BASE_URL = EFI_PRODUCTION_URL
-	if c.Sandbox {
-		EFI_BASE_URL = EFI_STAGING_URL
+	// Unmarshal the response body, errors included, into the target.
+	if out != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil && res.StatusCode == status {
+			return err
+		}
+	}
+
+	// Check if the response status is the expected one.
+	if res.StatusCode != status {
+		return newAPIError(res.StatusCode, body)
+	}
+
+	return nil
+}
+
+// authorized executes the request, replaying it once with a new token if the current one is rejected
+func (c *Client) authorized(req *http.Request) (*http.Response, []byte, error) {
+	res, body, err := c.execute(req)
+
+	// A revoked or rotated token is rejected before it expires; renew it and replay once.
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		if err := c.reauthenticate(req); err != nil {
+			return nil, nil, err
+		}
+
+		res, body, err = c.execute(req)
+	}
+
+	return res, body, err
+}
+
+// execute sends the request, retrying transient failures on requests that are safe to replay
+func (c *Client) execute(req *http.Request) (*http.Response, []byte, error) {
+	res, body, err := c.send(req)
+
+	for attempt := 1; attempt < c.credentials.Retry.attempts() && retryable(req, res, err); attempt++ {
+		if err := sleep(req.Context(), c.credentials.Retry.backoff(attempt, res)); err != nil {
+			return nil, nil, err
+		}
+
+		if err := rewind(req); err != nil {
+			return nil, nil, err
+		}
+
+		res, body, err = c.send(req)
+	}
+
+	return res, body, err
+}
+
+// reauthenticate drops the token used by the request and signs it with a new one
+func (c *Client) reauthenticate(req *http.Request) error {
+	if req.Body != nil && req.GetBody == nil {
+		return errors.New("request body cannot be replayed")
+	}
+
+	c.invalidateToken(req.Header.Get("authorization"))
+
+	token := c.OAuth(req.Context())
+	if token.Error != nil {
+		return token.Error
 	}
 
-	Client = &c
+	if err := rewind(req); err != nil {
+		return err
+	}
+	req.Header.Set("authorization", token.authorization())
 
 	return nil
 }
+
+// send executes the HTTP request once and reads the whole response body
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	// Execute the HTTP request.
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close() // Ensure the response body is closed after reading.
+
+	// Read the response body.
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res, nil, err
+	}
+
+	return res, body, nil
+}
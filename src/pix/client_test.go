@@ -0,0 +1,70 @@
+package pix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoReauthenticates(t *testing.T) {
+	var tokens, calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			if tokens.Add(1) == 1 {
+				writeToken(w, "revoked", 3600)
+				return
+			}
+			writeToken(w, "fresh", 3600)
+			return
+		}
+
+		calls.Add(1)
+		if r.Header.Get("authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// The replayed request must carry its original body.
+		var p Pix
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil || p.Chave != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"txid":"abc","status":"ATIVA"}`))
+	}))
+
+	p := &Pix{TxID: "abc", Chave: "key"}
+	if err := client.CreatePix(context.Background(), p); err != nil {
+		t.Fatalf("CreatePix: %v", err)
+	}
+	if p.Status != StatusAtiva {
+		t.Errorf("status = %q, want %q", p.Status, StatusAtiva)
+	}
+	if tokens.Load() != 2 || calls.Load() != 2 {
+		t.Errorf("made %d token requests and %d calls, want 2 and 2", tokens.Load(), calls.Load())
+	}
+}
+
+func TestDoReplaysOnlyOnce(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	err := client.FetchPix(context.Background(), &Pix{TxID: "abc"})
+	if !IsUnauthorized(err) {
+		t.Fatalf("FetchPix = %v, want an unauthorized error", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("made %d calls, want 2", n)
+	}
+}
@@ -0,0 +1,303 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Statuses of a recurring charge.
+const (
+	CobRCriada    = "CRIADA"
+	CobRAtiva     = "ATIVA"
+	CobRConcluida = "CONCLUIDA"
+	CobRExpirada  = "EXPIRADA"
+	CobRRejeitada = "REJEITADA"
+	CobRCancelada = "CANCELADA"
+)
+
+// CobR represents a charge issued under a Pix Automático recurrence.
+type CobR struct {
+	IDRec               string        `json:"idRec,omitempty"`               // Recurrence ID
+	TxID                string        `json:"txid,omitempty"`                // Transaction ID
+	InfoAdicional       string        `json:"infoAdicional,omitempty"`       // Additional information
+	Calendario          *Calendario   `json:"calendario,omitempty"`          // Due date
+	Valor               *Valor        `json:"valor,omitempty"`               // Amount of the charge
+	AjusteDiaUtil       *bool         `json:"ajusteDiaUtil,omitempty"`       // Whether the due date moves to the next business day
+	Devedor             *Devedor      `json:"devedor,omitempty"`             // Debtor information
+	Recebedor           *ContaBanco   `json:"recebedor,omitempty"`           // Account receiving the payment
+	Status              string        `json:"status,omitempty"`              // Charge status
+	PoliticaRetentativa string        `json:"politicaRetentativa,omitempty"` // Retry policy inherited from the recurrence
+	Tentativas          []Tentativa   `json:"tentativas,omitempty"`          // Settlement attempts
+	Atualizacao         []Atualizacao `json:"atualizacao,omitempty"`         // Status history
+	BadRequest
+}
+
+// Tentativa represents an attempt to settle a recurring charge.
+type Tentativa struct {
+	DataLiquidacao string        `json:"dataLiquidacao,omitempty"` // Settlement date of the attempt
+	Tipo           string        `json:"tipo,omitempty"`           // Attempt type
+	Status         string        `json:"status,omitempty"`         // Attempt status
+	EndToEndID     string        `json:"endToEndId,omitempty"`     // End-to-end ID of the attempt
+	Atualizacao    []Atualizacao `json:"atualizacao,omitempty"`    // Status history
+}
+
+// Create issues the recurring charge using the default client.
+func (cr *CobR) Create() error {
+	return cr.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (cr *CobR) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateCobR(ctx, cr)
+}
+
+// Fetch retrieves the recurring charge using the default client.
+func (cr *CobR) Fetch() error {
+	return cr.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (cr *CobR) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchCobR(ctx, cr)
+}
+
+// Update revises the recurring charge using the default client.
+func (cr *CobR) Update() error {
+	return cr.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (cr *CobR) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateCobR(ctx, cr)
+}
+
+// Cancel cancels the recurring charge using the default client.
+func (cr *CobR) Cancel() error {
+	return cr.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel but carries ctx through the request.
+func (cr *CobR) CancelContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CancelCobR(ctx, cr)
+}
+
+// Retry asks for a new settlement attempt on the given date using the default client.
+func (cr *CobR) Retry(data time.Time) error {
+	return cr.RetryContext(context.Background(), data)
+}
+
+// RetryContext is like Retry but carries ctx through the request.
+func (cr *CobR) RetryContext(ctx context.Context, data time.Time) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.RetryCobR(ctx, cr, data)
+}
+
+// ListCobR lists the recurring charges matching the filter using the default client.
+func ListCobR(filter CobRFilter) (*CobRList, error) {
+	return ListCobRContext(context.Background(), filter)
+}
+
+// ListCobRContext is like ListCobR but carries ctx through the request.
+func ListCobRContext(ctx context.Context, filter CobRFilter) (*CobRList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCobR(ctx, filter)
+}
+
+// CreateCobR issues a recurring charge, using PUT when the TxID is set.
+func (c *Client) CreateCobR(ctx context.Context, cr *CobR) error {
+	// Ensure that IDRec is provided; every charge belongs to a recurrence.
+	if cr.IDRec == "" {
+		return errors.New("idRec is required")
+	}
+
+	// Determine the HTTP method: POST lets the API assign the TxID.
+	method := http.MethodPost
+	if cr.TxID != "" {
+		method = http.MethodPut
+	}
+
+	// Create a new HTTP request with the charge as the body.
+	req, err := c.newRequest(ctx, method, cr, "v2", "cobr", cr.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobR object.
+	return c.do(req, cr, http.StatusCreated)
+}
+
+// FetchCobR retrieves the details of a recurring charge using its TxID.
+func (c *Client) FetchCobR(ctx context.Context, cr *CobR) error {
+	// Ensure that TxID is provided; it is required to fetch the charge.
+	if cr.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Create a new HTTP GET request for fetching the charge.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cobr", cr.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobR object.
+	return c.do(req, cr, http.StatusOK)
+}
+
+// UpdateCobR revises an existing recurring charge using its TxID.
+func (c *Client) UpdateCobR(ctx context.Context, cr *CobR) error {
+	// Ensure that TxID is provided; it is required to revise the charge.
+	if cr.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Send only the fields that can be revised.
+	revision := CobR{
+		InfoAdicional: cr.InfoAdicional,
+		AjusteDiaUtil: cr.AjusteDiaUtil,
+		Devedor:       cr.Devedor,
+	}
+	if cr.Calendario != nil && cr.Calendario.DataDeVencimento != "" {
+		revision.Calendario = &Calendario{DataDeVencimento: cr.Calendario.DataDeVencimento}
+	}
+	if cr.Valor != nil && cr.Valor.Original != "" {
+		revision.Valor = &Valor{Original: cr.Valor.Original}
+	}
+
+	// Create a new HTTP PATCH request with the revised fields as the body.
+	req, err := c.newRequest(ctx, http.MethodPatch, revision, "v2", "cobr", cr.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobR object.
+	return c.do(req, cr, http.StatusOK)
+}
+
+// CancelCobR cancels a recurring charge before it is settled.
+func (c *Client) CancelCobR(ctx context.Context, cr *CobR) error {
+	// Ensure that TxID is provided; it is required to cancel the charge.
+	if cr.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Create a new HTTP PATCH request setting the cancelled status.
+	req, err := c.newRequest(ctx, http.MethodPatch, CobR{Status: CobRCancelada}, "v2", "cobr", cr.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobR object.
+	return c.do(req, cr, http.StatusOK)
+}
+
+// RetryCobR asks for a new settlement attempt of a failed recurring charge on the given date.
+func (c *Client) RetryCobR(ctx context.Context, cr *CobR, data time.Time) error {
+	// Ensure that TxID is provided; it is required to retry the charge.
+	if cr.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Create a new HTTP POST request for the settlement date.
+	req, err := c.newRequest(ctx, http.MethodPost, nil, "v2", "cobr", cr.TxID, "retentativa", data.Format(time.DateOnly))
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobR object.
+	return c.do(req, cr, http.StatusOK)
+}
+
+// ListCobR lists the recurring charges created within the filter period.
+func (c *Client) ListCobR(ctx context.Context, filter CobRFilter) (*CobRList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing charges.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cobr")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &CobRList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// CobRFilter holds the filters for listing recurring charges.
+type CobRFilter struct {
+	Inicio    time.Time // Start of the creation period
+	Fim       time.Time // End of the creation period
+	IDRec     string    // Recurrence ID
+	CPF       string    // Debtor CPF
+	CNPJ      string    // Debtor CNPJ
+	Status    string    // Charge status
+	Convenio  string    // Agreement identifier
+	Paginacao Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f CobRFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.IDRec != "" {
+		query.Set("idRec", f.IDRec)
+	}
+	if f.CPF != "" {
+		query.Set("cpf", f.CPF)
+	}
+	if f.CNPJ != "" {
+		query.Set("cnpj", f.CNPJ)
+	}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	if f.Convenio != "" {
+		query.Set("convenio", f.Convenio)
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// CobRList represents a page of recurring charges.
+type CobRList struct {
+	Parametros Parametros `json:"parametros"`      // Filters and pagination of the page
+	CobsR      []CobR     `json:"cobsr,omitempty"` // Charges in the page
+	BadRequest
+}
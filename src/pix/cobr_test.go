@@ -0,0 +1,33 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateCobRSendsRevisableFields(t *testing.T) {
+	// A recurring charge as returned by Fetch, with its due date moved afterwards.
+	cr := &CobR{
+		IDRec:               "RR1",
+		TxID:                "abc",
+		Status:              CobRAtiva,
+		PoliticaRetentativa: RetentativaPermite3R7D,
+		Calendario:          &Calendario{Criacao: "2026-10-01T10:00:00Z", DataDeVencimento: "2026-11-05"},
+		Valor:               &Valor{Original: "35.00"},
+		Recebedor:           &ContaBanco{Agencia: "0001", Conta: "123456"},
+		Tentativas:          []Tentativa{{Status: "SOLICITADA"}},
+	}
+
+	body := sentBody(t, http.StatusOK, `{"txid":"abc"}`, func(c *Client) error {
+		return c.UpdateCobR(context.Background(), cr)
+	})
+
+	if got, want := keys(body), []string{"calendario", "valor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent fields %v, want %v", got, want)
+	}
+	if got := keys(body["calendario"]); !reflect.DeepEqual(got, []string{"dataDeVencimento"}) {
+		t.Errorf("sent calendario fields %v, want [dataDeVencimento]", got)
+	}
+}
@@ -0,0 +1,233 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Modalities for Multa and Abatimento.
+const (
+	ModalidadeValorFixo  = 1 // Fixed amount
+	ModalidadePercentual = 2 // Percentage of the original amount
+)
+
+// Modalities for Juros.
+const (
+	JurosValorDiaCorrido      = 1 // Amount per calendar day
+	JurosPercentualDiaCorrido = 2 // Percentage per calendar day
+	JurosPercentualMesCorrido = 3 // Percentage per month, calendar days
+	JurosPercentualAnoCorrido = 4 // Percentage per year, calendar days
+	JurosValorDiaUtil         = 5 // Amount per business day
+	JurosPercentualDiaUtil    = 6 // Percentage per business day
+	JurosPercentualMesDiaUtil = 7 // Percentage per month, business days
+	JurosPercentualAnoDiaUtil = 8 // Percentage per year, business days
+)
+
+// Modalities for Desconto.
+const (
+	DescontoValorFixoAteData                = 1 // Fixed amount until the dates in DescontoDataFixa
+	DescontoPercentualAteData               = 2 // Percentage until the dates in DescontoDataFixa
+	DescontoValorAntecipacaoDiaCorrido      = 3 // Amount per calendar day paid in advance
+	DescontoValorAntecipacaoDiaUtil         = 4 // Amount per business day paid in advance
+	DescontoPercentualAntecipacaoDiaCorrido = 5 // Percentage per calendar day paid in advance
+	DescontoPercentualAntecipacaoDiaUtil    = 6 // Percentage per business day paid in advance
+)
+
+// CobV represents a PIX charge with a due date.
+type CobV struct {
+	Calendario         *Calendario      `json:"calendario,omitempty"`         // Due date and validity
+	TxID               string           `json:"txid,omitempty"`               // Transaction ID
+	Revisao            int              `json:"revisao,omitempty"`            // Revision number
+	Loc                *Loc             `json:"loc,omitempty"`                // Location information
+	Location           string           `json:"location,omitempty"`           // Location string
+	Status             string           `json:"status,omitempty"`             // Charge status, or its status within a batch
+	Devedor            *Devedor         `json:"devedor,omitempty"`            // Debtor information
+	Recebedor          *Recebedor       `json:"recebedor,omitempty"`          // Receiver information
+	Valor              *Valor           `json:"valor,omitempty"`              // Amount, penalties and discounts
+	Chave              string           `json:"chave,omitempty"`              // Key for the charge
+	SolicitacaoPagador string           `json:"solicitacaoPagador,omitempty"` // Payer's request
+	InfoAdicionais     *[]InfoAdicional `json:"infoAdicionais,omitempty"`     // Additional information
+	PixCopiaECola      string           `json:"pixCopiaECola,omitempty"`      // Copy and paste PIX
+	Problema           *Problema        `json:"problema,omitempty"`           // Why a batch rejected the charge
+	BadRequest
+}
+
+// Create issues the charge with a due date using the default client.
+func (cv *CobV) Create() error {
+	return cv.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (cv *CobV) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateCobV(ctx, cv)
+}
+
+// Update revises the charge with a due date using the default client.
+func (cv *CobV) Update() error {
+	return cv.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (cv *CobV) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateCobV(ctx, cv)
+}
+
+// Fetch retrieves the charge with a due date using the default client.
+func (cv *CobV) Fetch() error {
+	return cv.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (cv *CobV) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchCobV(ctx, cv)
+}
+
+// ListCobV lists the charges with a due date matching the filter using the default client.
+func ListCobV(filter CobFilter) (*CobVList, error) {
+	return ListCobVContext(context.Background(), filter)
+}
+
+// ListCobVContext is like ListCobV but carries ctx through the request.
+func ListCobVContext(ctx context.Context, filter CobFilter) (*CobVList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCobV(ctx, filter)
+}
+
+// CreateCobV issues a charge with a due date under the caller-supplied TxID.
+func (c *Client) CreateCobV(ctx context.Context, cv *CobV) error {
+	// Ensure that TxID is provided; due-date charges are always created with PUT.
+	if cv.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Create a new HTTP PUT request with the charge as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, cv, "v2", "cobv", cv.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobV object.
+	return c.do(req, cv, http.StatusCreated)
+}
+
+// UpdateCobV revises an existing charge with a due date using its TxID.
+func (c *Client) UpdateCobV(ctx context.Context, cv *CobV) error {
+	// Ensure that TxID is provided; it is required to revise the charge.
+	if cv.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Send only the fields that can be revised.
+	revision := cv.payload()
+
+	// Create a new HTTP PATCH request with the revised fields as the body.
+	req, err := c.newRequest(ctx, http.MethodPatch, revision, "v2", "cobv", cv.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobV object.
+	return c.do(req, cv, http.StatusOK)
+}
+
+// FetchCobV retrieves the details of a charge with a due date using its TxID.
+func (c *Client) FetchCobV(ctx context.Context, cv *CobV) error {
+	// Ensure that TxID is provided; it is required to fetch the charge.
+	if cv.TxID == "" {
+		return errors.New("txid is required")
+	}
+
+	// Create a new HTTP GET request for fetching the charge.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cobv", cv.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the CobV object.
+	return c.do(req, cv, http.StatusOK)
+}
+
+// ListCobV lists the charges with a due date created within the filter period.
+func (c *Client) ListCobV(ctx context.Context, filter CobFilter) (*CobVList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing charges.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cobv")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &CobVList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// CobVList represents a page of charges with a due date.
+type CobVList struct {
+	Parametros Parametros `json:"parametros"`     // Filters and pagination of the page
+	Cobs       []CobV     `json:"cobs,omitempty"` // Charges in the page
+	BadRequest
+}
+
+// Recebedor represents the receiver's information.
+type Recebedor struct {
+	CNPJ         string `json:"cnpj,omitempty"`         // CNPJ (business taxpayer ID)
+	CPF          string `json:"cpf,omitempty"`          // CPF (individual taxpayer ID)
+	Nome         string `json:"nome,omitempty"`         // Name of the receiver
+	NomeFantasia string `json:"nomeFantasia,omitempty"` // Trade name
+	Logradouro   string `json:"logradouro,omitempty"`   // Address
+	Cidade       string `json:"cidade,omitempty"`       // City
+	UF           string `json:"uf,omitempty"`           // State
+	CEP          string `json:"cep,omitempty"`          // ZIP code
+}
+
+// payload returns the fields of the charge accepted by the API when it is
+// created or revised, leaving out those it reports back
+func (cv *CobV) payload() CobV {
+	p := CobV{
+		Devedor:            cv.Devedor,
+		Chave:              cv.Chave,
+		SolicitacaoPagador: cv.SolicitacaoPagador,
+		InfoAdicionais:     cv.InfoAdicionais,
+	}
+	if cv.Calendario != nil && (cv.Calendario.DataDeVencimento != "" || cv.Calendario.ValidadeAposVencimento != 0) {
+		p.Calendario = &Calendario{
+			DataDeVencimento:       cv.Calendario.DataDeVencimento,
+			ValidadeAposVencimento: cv.Calendario.ValidadeAposVencimento,
+		}
+	}
+	if cv.Loc != nil && cv.Loc.ID != 0 {
+		p.Loc = &Loc{ID: cv.Loc.ID}
+	}
+	if cv.Valor != nil {
+		valor := *cv.Valor
+		valor.Final = ""
+		p.Valor = &valor
+	}
+	return p
+}
@@ -0,0 +1,41 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateCobVSendsRevisableFields(t *testing.T) {
+	// A charge as returned by Fetch, with the due date changed afterwards.
+	cv := &CobV{
+		TxID:          "abc",
+		Status:        StatusAtiva,
+		Revisao:       2,
+		Location:      "pix.example.com/qr/v2/cobv/1",
+		PixCopiaECola: "000201",
+		Chave:         "key",
+		Calendario:    &Calendario{Criacao: "2026-10-01T10:00:00Z", DataDeVencimento: "2026-11-01", ValidadeAposVencimento: 30},
+		Loc:           &Loc{ID: 7, Location: "pix.example.com/qr/v2/cobv/1", TipoCob: TipoCobCobV},
+		Recebedor:     &Recebedor{Nome: "Loja"},
+		Valor:         &Valor{Original: "100.00", Final: "98.00"},
+	}
+
+	body := sentBody(t, http.StatusOK, `{"txid":"abc"}`, func(c *Client) error {
+		return c.UpdateCobV(context.Background(), cv)
+	})
+
+	if got, want := keys(body), []string{"calendario", "chave", "loc", "valor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent fields %v, want %v", got, want)
+	}
+	if got, want := keys(body["calendario"]), []string{"dataDeVencimento", "validadeAposVencimento"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent calendario fields %v, want %v", got, want)
+	}
+	if got := keys(body["loc"]); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("sent loc fields %v, want [id]", got)
+	}
+	if got := keys(body["valor"]); !reflect.DeepEqual(got, []string{"original"}) {
+		t.Errorf("sent valor fields %v, want [original]", got)
+	}
+}
@@ -0,0 +1,190 @@
+package pix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+)
+
+// ConfigConta represents the account configuration.
+// Fields left nil are not changed by Update.
+type ConfigConta struct {
+	Pix *ConfigPix `json:"pix,omitempty"` // PIX settings
+	BadRequest
+}
+
+// ConfigPix holds the PIX settings of the account.
+type ConfigPix struct {
+	ReceberSemChave *bool                  `json:"receberSemChave,omitempty"` // Accept PIX sent to the account without a key
+	Chaves          map[string]ConfigChave `json:"chaves,omitempty"`          // Settings of each key
+}
+
+// ConfigChave holds the settings of a PIX key.
+type ConfigChave struct {
+	Recebimento *ConfigRecebimento `json:"recebimento,omitempty"` // Receipt behaviour
+	Envio       *ConfigEnvio       `json:"envio,omitempty"`       // Outbound PIX behaviour
+}
+
+// ConfigRecebimento holds how a key receives PIX.
+type ConfigRecebimento struct {
+	TxidObrigatorio *bool                     `json:"txidObrigatorio,omitempty"` // Reject PIX without a TxID
+	QRCodeEstatico  *ConfigQRCodeEstatico     `json:"qrCodeEstatico,omitempty"`  // Static QR code behaviour
+	Webhook         *ConfigWebhookRecebimento `json:"webhook,omitempty"`         // Received PIX notifications
+}
+
+// ConfigQRCodeEstatico holds how a key handles static QR codes.
+type ConfigQRCodeEstatico struct {
+	RecusarTodos *bool `json:"recusarTodos,omitempty"` // Reject every payment of a static QR code
+}
+
+// ConfigWebhookRecebimento holds what the webhook reports for received PIX.
+type ConfigWebhookRecebimento struct {
+	Notificacao *ConfigNotificacao `json:"notificacao,omitempty"` // Extra data sent in notifications
+	Notificar   *ConfigNotificar   `json:"notificar,omitempty"`   // Which PIX are notified
+}
+
+// ConfigEnvio holds how a key reports outbound PIX and refunds.
+type ConfigEnvio struct {
+	Webhook *ConfigWebhookEnvio `json:"webhook,omitempty"` // Sent PIX notifications
+}
+
+// ConfigWebhookEnvio holds what the webhook reports for sent PIX.
+type ConfigWebhookEnvio struct {
+	Notificacao *ConfigNotificacao `json:"notificacao,omitempty"` // Extra data sent in notifications
+}
+
+// ConfigNotificacao holds the extra data sent in webhook notifications.
+type ConfigNotificacao struct {
+	Tarifa     *bool `json:"tarifa,omitempty"`     // Include the tariff charged
+	Pagador    *bool `json:"pagador,omitempty"`    // Include the payer's data
+	Favorecido *bool `json:"favorecido,omitempty"` // Include the recipient's data
+}
+
+// ConfigNotificar holds which received PIX are notified.
+type ConfigNotificar struct {
+	PixSemTxid *bool `json:"pixSemTxid,omitempty"` // Notify PIX received without a TxID
+}
+
+// Fetch retrieves the account configuration using the default client.
+func (cfg *ConfigConta) Fetch() error {
+	return cfg.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (cfg *ConfigConta) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchConfigConta(ctx, cfg)
+}
+
+// Update applies the account configuration using the default client.
+func (cfg *ConfigConta) Update() error {
+	return cfg.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (cfg *ConfigConta) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateConfigConta(ctx, cfg)
+}
+
+// FetchConfigConta retrieves the account configuration.
+func (c *Client) FetchConfigConta(ctx context.Context, cfg *ConfigConta) error {
+	// Create a new HTTP GET request for fetching the configuration.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "config")
+	if err != nil {
+		return err
+	}
+
+	// Start from an empty configuration so no stale setting survives.
+	*cfg = ConfigConta{}
+
+	// Execute the request and unmarshal the response into the ConfigConta object.
+	return c.do(req, cfg, http.StatusOK)
+}
+
+// UpdateConfigConta applies the account configuration.
+func (c *Client) UpdateConfigConta(ctx context.Context, cfg *ConfigConta) error {
+	// Send only the settings, never a previous error.
+	body := ConfigConta{Pix: cfg.Pix}
+
+	// Create a new HTTP PUT request with the configuration as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, body, "v2", "gn", "config")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request; the configuration is accepted to be applied.
+	return c.do(req, cfg, http.StatusAccepted)
+}
+
+// Diff lists the settings that change when going from cfg to desired, one
+// "path: current -> desired" line per setting, sorted by path. Settings left
+// nil in desired are not listed, since Update does not change them; settings
+// missing from cfg are shown as "unset".
+func (cfg ConfigConta) Diff(desired ConfigConta) ([]string, error) {
+	current, err := flattenConfig(ConfigConta{Pix: cfg.Pix})
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := flattenConfig(ConfigConta{Pix: desired.Pix})
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []string
+	for path, value := range target {
+		old, ok := current[path]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("%s: unset -> %v", path, value))
+		case !reflect.DeepEqual(old, value):
+			diff = append(diff, fmt.Sprintf("%s: %v -> %v", path, old, value))
+		}
+	}
+	slices.Sort(diff)
+
+	return diff, nil
+}
+
+// flattenConfig maps every setting of the configuration to its JSON path.
+func flattenConfig(cfg ConfigConta) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for key, value := range node {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			if child, ok := value.(map[string]interface{}); ok {
+				walk(path, child)
+				continue
+			}
+			values[path] = value
+		}
+	}
+	walk("", tree)
+
+	return values, nil
+}
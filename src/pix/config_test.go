@@ -0,0 +1,96 @@
+package pix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigContaDiff(t *testing.T) {
+	on, off := true, false
+
+	current := ConfigConta{Pix: &ConfigPix{
+		ReceberSemChave: &on,
+		Chaves: map[string]ConfigChave{
+			"a@example.com": {Recebimento: &ConfigRecebimento{TxidObrigatorio: &off}},
+			"b": {Recebimento: &ConfigRecebimento{
+				Webhook: &ConfigWebhookRecebimento{Notificacao: &ConfigNotificacao{Tarifa: &on}},
+			}},
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		current ConfigConta
+		desired ConfigConta
+		want    []string
+	}{
+		{
+			name:    "identical",
+			current: current,
+			desired: current,
+			want:    nil,
+		},
+		{
+			name:    "nil settings are skipped",
+			current: current,
+			desired: ConfigConta{Pix: &ConfigPix{Chaves: map[string]ConfigChave{"b": {}}}},
+			want:    nil,
+		},
+		{
+			name:    "empty desired",
+			current: current,
+			desired: ConfigConta{},
+			want:    nil,
+		},
+		{
+			name:    "changed per key",
+			current: current,
+			desired: ConfigConta{Pix: &ConfigPix{Chaves: map[string]ConfigChave{
+				"a@example.com": {Recebimento: &ConfigRecebimento{TxidObrigatorio: &on}},
+			}}},
+			want: []string{"pix.chaves.a@example.com.recebimento.txidObrigatorio: false -> true"},
+		},
+		{
+			name:    "added setting",
+			current: current,
+			desired: ConfigConta{Pix: &ConfigPix{Chaves: map[string]ConfigChave{
+				"b": {Envio: &ConfigEnvio{Webhook: &ConfigWebhookEnvio{Notificacao: &ConfigNotificacao{Pagador: &on}}}},
+			}}},
+			want: []string{"pix.chaves.b.envio.webhook.notificacao.pagador: unset -> true"},
+		},
+		{
+			name:    "from an empty configuration",
+			current: ConfigConta{},
+			desired: ConfigConta{Pix: &ConfigPix{ReceberSemChave: &off}},
+			want:    []string{"pix.receberSemChave: unset -> false"},
+		},
+		{
+			name:    "sorted by path",
+			current: current,
+			desired: ConfigConta{Pix: &ConfigPix{
+				ReceberSemChave: &off,
+				Chaves: map[string]ConfigChave{
+					"b": {Recebimento: &ConfigRecebimento{
+						Webhook: &ConfigWebhookRecebimento{Notificacao: &ConfigNotificacao{Tarifa: &off}},
+					}},
+					"a@example.com": {Recebimento: &ConfigRecebimento{QRCodeEstatico: &ConfigQRCodeEstatico{RecusarTodos: &on}}},
+				},
+			}},
+			want: []string{
+				"pix.chaves.a@example.com.recebimento.qrCodeEstatico.recusarTodos: unset -> true",
+				"pix.chaves.b.recebimento.webhook.notificacao.tarifa: true -> false",
+				"pix.receberSemChave: true -> false",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.current.Diff(tt.desired)
+		if err != nil {
+			t.Fatalf("%s: Diff: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Diff = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Natures of a refund.
+const (
+	NaturezaOriginal = "ORIGINAL" // Refund of a regular payment
+	NaturezaRetirada = "RETIRADA" // Refund of a Pix Saque or Pix Troco withdrawal
+)
+
+// Statuses of a refund.
+const (
+	DevolucaoEmProcessamento = "EM_PROCESSAMENTO"
+	DevolucaoDevolvido       = "DEVOLVIDO"
+	DevolucaoNaoRealizado    = "NAO_REALIZADO"
+)
+
+// Devolucao represents a refund of a received PIX.
+type Devolucao struct {
+	EndToEndID string   `json:"-"`                   // End-to-end ID of the refunded payment
+	ID         string   `json:"id,omitempty"`        // Refund ID chosen by the receiver
+	RtrID      string   `json:"rtrId,omitempty"`     // Return ID of the refund
+	Valor      string   `json:"valor,omitempty"`     // Refunded amount
+	Natureza   string   `json:"natureza,omitempty"`  // Refund nature
+	Descricao  string   `json:"descricao,omitempty"` // Message sent to the payer
+	Horario    *Horario `json:"horario,omitempty"`   // Request and settlement timestamps
+	Status     string   `json:"status,omitempty"`    // Refund status
+	Motivo     string   `json:"motivo,omitempty"`    // Reason reported for the status
+	BadRequest
+}
+
+// Final reports whether the refund reached a status that will not change anymore.
+func (d *Devolucao) Final() bool {
+	return d.Status == DevolucaoDevolvido || d.Status == DevolucaoNaoRealizado
+}
+
+// Create requests the refund using the default client.
+func (d *Devolucao) Create() error {
+	return d.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (d *Devolucao) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateDevolucao(ctx, d)
+}
+
+// Fetch retrieves the refund using the default client.
+func (d *Devolucao) Fetch() error {
+	return d.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (d *Devolucao) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchDevolucao(ctx, d)
+}
+
+// Wait polls the refund until it reaches a final status using the default client.
+func (d *Devolucao) Wait(interval, timeout time.Duration) error {
+	return d.WaitContext(context.Background(), interval, timeout)
+}
+
+// WaitContext is like Wait but carries ctx through the requests.
+func (d *Devolucao) WaitContext(ctx context.Context, interval, timeout time.Duration) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.WaitDevolucao(ctx, d, interval, timeout)
+}
+
+// CreateDevolucao requests a full or partial refund of a received PIX.
+// The caller-supplied ID makes the request safe to repeat.
+func (c *Client) CreateDevolucao(ctx context.Context, d *Devolucao) error {
+	// Ensure the payment, the refund ID and the amount are provided.
+	if d.EndToEndID == "" || d.ID == "" {
+		return errors.New("endToEndId and id are required")
+	}
+	if d.Valor == "" {
+		return errors.New("valor is required")
+	}
+
+	// Send only the fields accepted by the API.
+	body := Devolucao{Valor: d.Valor, Natureza: d.Natureza, Descricao: d.Descricao}
+
+	// Create a new HTTP PUT request with the refund as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, body, "v2", "pix", d.EndToEndID, "devolucao", d.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Devolucao object.
+	return c.do(req, d, http.StatusCreated)
+}
+
+// FetchDevolucao retrieves a refund using the payment end-to-end ID and the refund ID.
+func (c *Client) FetchDevolucao(ctx context.Context, d *Devolucao) error {
+	// Ensure the payment and the refund ID are provided.
+	if d.EndToEndID == "" || d.ID == "" {
+		return errors.New("endToEndId and id are required")
+	}
+
+	// Create a new HTTP GET request for fetching the refund.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "pix", d.EndToEndID, "devolucao", d.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Devolucao object.
+	return c.do(req, d, http.StatusOK)
+}
+
+// WaitDevolucao polls the refund every interval until it reaches a final status
+// or the timeout expires.
+func (c *Client) WaitDevolucao(ctx context.Context, d *Devolucao, interval, timeout time.Duration) error {
+	err := poll(ctx, interval, timeout, func(ctx context.Context) (bool, error) {
+		if err := c.FetchDevolucao(ctx, d); err != nil {
+			return false, err
+		}
+		return d.Final(), nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return fmt.Errorf("refund %s still %s: %w", d.ID, d.Status, err)
+	}
+	return err
+}
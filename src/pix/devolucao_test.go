@@ -0,0 +1,41 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitDevolucao(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+
+		if calls.Add(1) < 3 {
+			w.Write([]byte(`{"id":"d1","status":"EM_PROCESSAMENTO"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"d1","status":"DEVOLVIDO"}`))
+	}))
+
+	d := &Devolucao{EndToEndID: "E1", ID: "d1"}
+	if err := client.WaitDevolucao(context.Background(), d, time.Millisecond, time.Second); err != nil {
+		t.Fatalf("WaitDevolucao: %v", err)
+	}
+	if d.Status != DevolucaoDevolvido || calls.Load() != 3 {
+		t.Errorf("status %q after %d polls, want %q after 3", d.Status, calls.Load(), DevolucaoDevolvido)
+	}
+
+	calls.Store(0)
+	if err := client.WaitDevolucao(context.Background(), d, 0, time.Second); err == nil {
+		t.Error("WaitDevolucao with a zero interval succeeded")
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("zero interval made %d polls, want 0", n)
+	}
+}
@@ -0,0 +1,201 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Account types for ContaBanco.
+const (
+	TipoContaCorrente  = "cacc" // Checking account
+	TipoContaPoupanca  = "svgs" // Savings account
+	TipoContaPagamento = "tran" // Payment account
+)
+
+// Statuses of an outbound PIX.
+const (
+	EnvioEmProcessamento = "EM_PROCESSAMENTO"
+	EnvioRealizado       = "REALIZADO"
+	EnvioNaoRealizado    = "NAO_REALIZADO"
+)
+
+// Envio represents a PIX sent from the account.
+type Envio struct {
+	IDEnvio       string      `json:"idEnvio,omitempty"`       // Send ID chosen by the caller
+	EndToEndID    string      `json:"endToEndId,omitempty"`    // End-to-end ID of the transfer
+	E2EID         string      `json:"e2eId,omitempty"`         // End-to-end ID as returned when sending
+	Valor         string      `json:"valor,omitempty"`         // Amount sent
+	Chave         string      `json:"chave,omitempty"`         // Key that received the transfer
+	Status        string      `json:"status,omitempty"`        // Transfer status
+	InfoPagador   string      `json:"infoPagador,omitempty"`   // Message sent to the recipient
+	Horario       *Horario    `json:"horario,omitempty"`       // Request and settlement timestamps
+	Pagador       *Pagador    `json:"pagador,omitempty"`       // Key of the account sending the transfer
+	Favorecido    *Favorecido `json:"favorecido,omitempty"`    // Recipient key or bank account
+	PixCopiaECola string      `json:"pixCopiaECola,omitempty"` // QR code paid by the transfer
+	BadRequest
+}
+
+// Create sends the PIX using the default client.
+func (e *Envio) Create() error {
+	return e.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (e *Envio) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateEnvio(ctx, e)
+}
+
+// Fetch retrieves the sent PIX using the default client.
+func (e *Envio) Fetch() error {
+	return e.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (e *Envio) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchEnvio(ctx, e)
+}
+
+// ListEnvio lists the sent PIX matching the filter using the default client.
+func ListEnvio(filter EnvioFilter) (*EnvioList, error) {
+	return ListEnvioContext(context.Background(), filter)
+}
+
+// ListEnvioContext is like ListEnvio but carries ctx through the request.
+func ListEnvioContext(ctx context.Context, filter EnvioFilter) (*EnvioList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListEnvio(ctx, filter)
+}
+
+// CreateEnvio sends a PIX to a key or to a bank account. The caller-supplied
+// IDEnvio identifies the transfer, so repeating the call never sends it twice.
+func (c *Client) CreateEnvio(ctx context.Context, e *Envio) error {
+	// Ensure the send ID, the amount and both parties are provided.
+	if e.IDEnvio == "" {
+		return errors.New("idEnvio is required")
+	}
+	if e.Valor == "" {
+		return errors.New("valor is required")
+	}
+	if e.Pagador == nil || e.Pagador.Chave == "" {
+		return errors.New("pagador.chave is required")
+	}
+	if e.Favorecido == nil || (e.Favorecido.Chave == "") == (e.Favorecido.ContaBanco == nil) {
+		return errors.New("favorecido requires either chave or contaBanco")
+	}
+
+	// Send only the fields accepted by the API.
+	body := Envio{Valor: e.Valor, Pagador: e.Pagador, Favorecido: e.Favorecido}
+
+	// Create a new HTTP PUT request with the transfer as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, body, "v3", "gn", "pix", e.IDEnvio)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Envio object.
+	if err := c.do(req, e, http.StatusCreated); err != nil {
+		return err
+	}
+
+	if e.EndToEndID == "" {
+		e.EndToEndID = e.E2EID
+	}
+
+	return nil
+}
+
+// FetchEnvio retrieves a sent PIX by its end-to-end ID or, when it is not set, by its IDEnvio.
+func (c *Client) FetchEnvio(ctx context.Context, e *Envio) error {
+	// Select the endpoint from the identifier provided.
+	var path []string
+	switch {
+	case e.EndToEndID != "":
+		path = []string{"v2", "gn", "pix", "enviados", e.EndToEndID}
+	case e.IDEnvio != "":
+		path = []string{"v2", "gn", "pix", "enviados", "id-envio", e.IDEnvio}
+	default:
+		return errors.New("endToEndId or idEnvio is required")
+	}
+
+	// Create a new HTTP GET request for fetching the transfer.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, path...)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Envio object.
+	return c.do(req, e, http.StatusOK)
+}
+
+// ListEnvio lists the PIX sent within the filter period.
+func (c *Client) ListEnvio(ctx context.Context, filter EnvioFilter) (*EnvioList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing transfers.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "pix", "enviados")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &EnvioList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// EnvioFilter holds the filters for listing sent PIX.
+type EnvioFilter struct {
+	Inicio            time.Time // Start of the period
+	Fim               time.Time // End of the period
+	Status            string    // Transfer status
+	DevolucaoPresente *bool     // Whether the transfer has refunds
+	Paginacao         Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f EnvioFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	if f.DevolucaoPresente != nil {
+		query.Set("devolucaoPresente", strconv.FormatBool(*f.DevolucaoPresente))
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// EnvioList represents a page of sent PIX.
+type EnvioList struct {
+	Parametros Parametros `json:"parametros"`    // Filters and pagination of the page
+	Pix        []Envio    `json:"pix,omitempty"` // Transfers in the page
+	BadRequest
+}
@@ -0,0 +1,120 @@
+package pix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the API answers with an unexpected status
+type APIError struct {
+	StatusCode int     // HTTP status code of the response
+	Name       string  // Error name reported by Efí
+	Message    string  // Human readable error message
+	Violations []Error // Field violations, when the payload was rejected
+	Body       []byte  // Raw response body
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("efi: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Name != "" {
+		msg += ": " + e.Name
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	for _, v := range e.Violations {
+		msg += fmt.Sprintf(" [%s: %s]", v.Path, v.Message)
+	}
+	return msg
+}
+
+// errorBody covers the error formats returned by the different Efí endpoints
+type errorBody struct {
+	BadRequest
+	Nome     string `json:"nome"`
+	Mensagem string `json:"mensagem"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Erros    []struct {
+		Chave    string `json:"chave"`
+		Caminho  string `json:"caminho"`
+		Mensagem string `json:"mensagem"`
+	} `json:"erros"`
+	Violacoes []Violacao `json:"violacoes"`
+}
+
+// newAPIError builds an APIError from a response status and body
+func newAPIError(status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Body: body}
+
+	var b errorBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		return e
+	}
+
+	e.Name = firstOf(b.Name, b.Nome, b.BadRequest.Error, b.Title)
+	e.Message = firstOf(b.Message, b.Mensagem, b.ErrorDescription, b.Detail)
+
+	if b.Errors != nil {
+		e.Violations = append(e.Violations, *b.Errors...)
+	}
+	for _, v := range b.Erros {
+		e.Violations = append(e.Violations, Error{Key: v.Chave, Path: v.Caminho, Message: v.Mensagem})
+	}
+	for _, v := range b.Violacoes {
+		e.Violations = append(e.Violations, Error{Path: v.Propriedade, Message: v.Razao})
+	}
+
+	return e
+}
+
+// firstOf returns the first non-empty string
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// hasStatus reports whether err is an APIError with one of the given statuses
+func hasStatus(err error, statuses ...int) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+	for _, status := range statuses {
+		if e.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether the API answered that the resource does not exist
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether the API rejected the credentials or token
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsValidation reports whether the API rejected the payload
+func IsValidation(err error) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+	return len(e.Violations) > 0 || e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsRateLimited reports whether the API throttled the request
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,120 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantName   string
+		wantMsg    string
+		violations []Error
+	}{
+		{
+			name:     "name and message",
+			status:   http.StatusNotFound,
+			body:     `{"name":"cobranca_nao_encontrada","message":"Nenhuma cobrança encontrada"}`,
+			wantName: "cobranca_nao_encontrada",
+			wantMsg:  "Nenhuma cobrança encontrada",
+		},
+		{
+			name:       "name, message and errors",
+			status:     http.StatusBadRequest,
+			body:       `{"name":"json_invalido","message":"Valor inválido","errors":[{"key":"type","path":"/valor/original","message":"deve ser string"}]}`,
+			wantName:   "json_invalido",
+			wantMsg:    "Valor inválido",
+			violations: []Error{{Key: "type", Path: "/valor/original", Message: "deve ser string"}},
+		},
+		{
+			name:       "nome, mensagem and erros",
+			status:     http.StatusBadRequest,
+			body:       `{"nome":"documento_bloqueado","mensagem":"Documento bloqueado","erros":[{"chave":"cpf","caminho":"/devedor/cpf","mensagem":"inválido"}]}`,
+			wantName:   "documento_bloqueado",
+			wantMsg:    "Documento bloqueado",
+			violations: []Error{{Key: "cpf", Path: "/devedor/cpf", Message: "inválido"}},
+		},
+		{
+			name:     "oauth error",
+			status:   http.StatusUnauthorized,
+			body:     `{"error":"invalid_client","error_description":"Credenciais inválidas"}`,
+			wantName: "invalid_client",
+			wantMsg:  "Credenciais inválidas",
+		},
+		{
+			name:       "problem details with violacoes",
+			status:     http.StatusUnprocessableEntity,
+			body:       `{"title":"Cobrança inválida","detail":"A requisição não respeita o schema","violacoes":[{"razao":"campo obrigatório","propriedade":"cob.calendario.dataDeVencimento"}]}`,
+			wantName:   "Cobrança inválida",
+			wantMsg:    "A requisição não respeita o schema",
+			violations: []Error{{Path: "cob.calendario.dataDeVencimento", Message: "campo obrigatório"}},
+		},
+		{
+			name:   "not json",
+			status: http.StatusBadGateway,
+			body:   `<html>bad gateway</html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("fetching charge: %w", newAPIError(tt.status, []byte(tt.body)))
+
+		var e *APIError
+		if !errors.As(err, &e) {
+			t.Fatalf("%s: errors.As did not find the APIError", tt.name)
+		}
+		if e.StatusCode != tt.status || e.Name != tt.wantName || e.Message != tt.wantMsg {
+			t.Errorf("%s: got %d %q %q, want %d %q %q", tt.name, e.StatusCode, e.Name, e.Message, tt.status, tt.wantName, tt.wantMsg)
+		}
+		if !reflect.DeepEqual(e.Violations, tt.violations) {
+			t.Errorf("%s: violations = %+v, want %+v", tt.name, e.Violations, tt.violations)
+		}
+		if string(e.Body) != tt.body {
+			t.Errorf("%s: body = %q, want the raw response", tt.name, e.Body)
+		}
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	wrap := func(status int, body string) error {
+		return fmt.Errorf("request failed: %w", newAPIError(status, []byte(body)))
+	}
+
+	tests := []struct {
+		name                                        string
+		err                                         error
+		notFound, unauthorized, validation, limited bool
+	}{
+		{"not found", wrap(http.StatusNotFound, ""), true, false, false, false},
+		{"unauthorized", wrap(http.StatusUnauthorized, ""), false, true, false, false},
+		{"forbidden", wrap(http.StatusForbidden, ""), false, true, false, false},
+		{"bad request", wrap(http.StatusBadRequest, ""), false, false, true, false},
+		{"unprocessable", wrap(http.StatusUnprocessableEntity, ""), false, false, true, false},
+		{"conflict with violations", wrap(http.StatusConflict, `{"violacoes":[{"razao":"r","propriedade":"p"}]}`), false, false, true, false},
+		{"rate limited", wrap(http.StatusTooManyRequests, ""), false, false, false, true},
+		{"server error", wrap(http.StatusInternalServerError, ""), false, false, false, false},
+		{"other error", errors.New("connection refused"), false, false, false, false},
+		{"nil", nil, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNotFound(tt.err); got != tt.notFound {
+			t.Errorf("%s: IsNotFound = %v", tt.name, got)
+		}
+		if got := IsUnauthorized(tt.err); got != tt.unauthorized {
+			t.Errorf("%s: IsUnauthorized = %v", tt.name, got)
+		}
+		if got := IsValidation(tt.err); got != tt.validation {
+			t.Errorf("%s: IsValidation = %v", tt.name, got)
+		}
+		if got := IsRateLimited(tt.err); got != tt.limited {
+			t.Errorf("%s: IsRateLimited = %v", tt.name, got)
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package pix
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // BadRequest represents a detailed error response
@@ -43,6 +48,84 @@ func decodeJWT(token string) (map[string]interface{}, error) {
 	return claims, nil
 }
 
+// parseCentavos converts a decimal amount such as "1234.56" into cents
+// without going through floating point
+func parseCentavos(amount string) (int64, error) {
+	invalid := fmt.Errorf("invalid amount %q", amount)
+
+	value := strings.TrimPrefix(amount, "-")
+	negative := len(value) != len(amount)
+
+	units, fraction, _ := strings.Cut(value, ".")
+	if units == "" || len(fraction) > 2 {
+		return 0, invalid
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	for _, r := range units + fraction {
+		if r < '0' || r > '9' {
+			return 0, invalid
+		}
+	}
+
+	centavos, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+
+	if negative {
+		centavos = -centavos
+	}
+
+	return centavos, nil
+}
+
+// periodQuery returns the query string for listing resources over a period
+func periodQuery(inicio, fim time.Time) (url.Values, error) {
+	if inicio.IsZero() || fim.IsZero() {
+		return nil, errors.New("inicio and fim are required")
+	}
+
+	query := url.Values{}
+	query.Set("inicio", inicio.Format(time.RFC3339))
+	query.Set("fim", fim.Format(time.RFC3339))
+
+	return query, nil
+}
+
+// setQuery adds the pagination parameters to the query string
+func (p Paginacao) setQuery(query url.Values) {
+	if p.PaginaAtual > 0 {
+		query.Set("paginacao.paginaAtual", strconv.Itoa(p.PaginaAtual))
+	}
+	if p.ItensPorPagina > 0 {
+		query.Set("paginacao.itensPorPagina", strconv.Itoa(p.ItensPorPagina))
+	}
+}
+
+// poll calls check every interval until it reports done or fails, or the
+// timeout expires. check receives the context bounded by the timeout
+func poll(ctx context.Context, interval, timeout time.Duration, check func(context.Context) (bool, error)) error {
+	// A zero interval would poll the API back-to-back until the timeout.
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
 const (
 	EFI_PRODUCTION_URL = "https://pix.api.efipay.com.br"
 	EFI_STAGING_URL    = "https://pix-h.api.efipay.com.br"
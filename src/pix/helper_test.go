@@ -0,0 +1,68 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCentavos(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   int64
+	}{
+		{"0", 0},
+		{"0.00", 0},
+		{"1234.56", 123456},
+		{"10.5", 1050},
+		{"7", 700},
+		{"-3.20", -320},
+		{"0.01", 1},
+		{"92233720368547758.07", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCentavos(tt.amount)
+		if err != nil || got != tt.want {
+			t.Errorf("parseCentavos(%q) = %d, %v; want %d", tt.amount, got, err, tt.want)
+		}
+	}
+
+	for _, amount := range []string{"", ".50", "1.234", "1,50", "1e3", "abc", "-", "1.-5", "92233720368547758.08"} {
+		if got, err := parseCentavos(amount); err == nil {
+			t.Errorf("parseCentavos(%q) = %d, want an error", amount, got)
+		}
+	}
+}
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	countTo := func(n int) func(context.Context) (bool, error) {
+		calls = 0
+		return func(context.Context) (bool, error) {
+			calls++
+			return calls == n, nil
+		}
+	}
+
+	if err := poll(context.Background(), time.Millisecond, time.Second, countTo(3)); err != nil || calls != 3 {
+		t.Errorf("poll = %v after %d checks, want nil after 3", err, calls)
+	}
+
+	if err := poll(context.Background(), 0, time.Second, countTo(3)); err == nil || calls != 0 {
+		t.Errorf("poll with a zero interval = %v after %d checks, want an error before checking", err, calls)
+	}
+
+	if err := poll(context.Background(), time.Millisecond, 20*time.Millisecond, countTo(-1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("poll past the timeout = %v, want context.DeadlineExceeded", err)
+	}
+
+	failure := errors.New("boom")
+	err := poll(context.Background(), time.Millisecond, time.Second, func(context.Context) (bool, error) {
+		return false, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("poll with a failing check = %v, want %v", err, failure)
+	}
+}
@@ -1,15 +1,35 @@
 package pix
 
 import (
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
+	"fmt"
 	"net/http"
-	"net/url"
-	"time"
+	"slices"
 )
 
+// KeySelector chooses, among the account keys, the one used by charges created without a key.
+type KeySelector func(chaves []string) (string, error)
+
+// FirstKey selects the first key returned by the API. Set it as the
+// KeySelector to keep that behaviour on accounts with several keys.
+func FirstKey(chaves []string) (string, error) {
+	if len(chaves) == 0 {
+		return "", errors.New("no pix keys found")
+	}
+	return chaves[0], nil
+}
+
+// PreferredKey selects the given key, failing if the account does not have it.
+func PreferredKey(chave string) KeySelector {
+	return func(chaves []string) (string, error) {
+		if !slices.Contains(chaves, chave) {
+			return "", fmt.Errorf("pix key %s not found", chave)
+		}
+		return chave, nil
+	}
+}
+
 // Key represents the structure for storing PIX keys.
 type Key struct {
 	Chaves     []string `json:"chaves,omitempty"` // List of PIX keys
@@ -17,68 +37,106 @@ type Key struct {
 	BadRequest          // Embedding BadRequest for error handling
 }
 
-// Fetch retrieves the available PIX keys from the server.
+// Fetch retrieves the available PIX keys using the default client.
 func (k *Key) Fetch() error {
-	// Obtain an OAuth token for authentication.
-	token := OAuth()
-	if token.Error != nil {
-		return token.Error
-	}
+	return k.FetchContext(context.Background())
+}
 
-	// Load the client certificate for secure communication.
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
+// FetchContext is like Fetch but carries ctx through the request.
+func (k *Key) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.FetchKeys(ctx, k)
+}
 
-	// Set up the HTTP client with a timeout and TLS configuration.
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
+// Create registers a new random PIX key using the default client.
+func (k *Key) Create() error {
+	return k.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (k *Key) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
 	}
+	return client.CreateKey(ctx, k)
+}
+
+// Delete removes the PIX key in Chave using the default client.
+func (k *Key) Delete() error {
+	return k.DeleteContext(context.Background())
+}
 
-	// Construct the request path for fetching PIX keys.
-	path, err := url.JoinPath(EFI_BASE_URL, "v2", "gn", "evp")
+// DeleteContext is like Delete but carries ctx through the request.
+func (k *Key) DeleteContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.DeleteKey(ctx, k)
+}
 
-	// Create a new HTTP GET request.
-	req, err := http.NewRequest(http.MethodGet, path, nil)
+// FetchKeys retrieves the available PIX keys from the server.
+func (c *Client) FetchKeys(ctx context.Context, k *Key) error {
+	// Create a new HTTP GET request for fetching PIX keys.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "evp")
 	if err != nil {
 		return err
 	}
 
-	// Set the appropriate headers for the request.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("authorization", authorization())
+	// Execute the request and unmarshal the response into the Key object.
+	return c.do(req, k, http.StatusOK)
+}
 
-	// Execute the HTTP request.
-	res, err := client.Do(req)
+// CreateKey registers a new random PIX key and stores it in Chave.
+func (c *Client) CreateKey(ctx context.Context, k *Key) error {
+	// Create a new HTTP POST request for creating a random key.
+	req, err := c.newRequest(ctx, http.MethodPost, nil, "v2", "gn", "evp")
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close() // Ensure the response body is closed after reading.
 
-	// Read the response body.
-	body, err := io.ReadAll(res.Body)
+	// Execute the request and unmarshal the response into the Key object.
+	return c.do(req, k, http.StatusCreated)
+}
+
+// DeleteKey removes the PIX key in Chave.
+func (c *Client) DeleteKey(ctx context.Context, k *Key) error {
+	// Ensure that the key is provided; it is required to delete it.
+	if k.Chave == "" {
+		return errors.New("chave is required")
+	}
+
+	// Create a new HTTP DELETE request to remove the key.
+	req, err := c.newRequest(ctx, http.MethodDelete, nil, "v2", "gn", "evp", k.Chave)
 	if err != nil {
 		return err
 	}
 
-	// Unmarshal the response body into the Key object.
-	if err := json.Unmarshal(body, &k); err != nil {
-		return err
+	// Execute the request and unmarshal the response into the Key object.
+	return c.do(req, k, http.StatusOK)
+}
+
+// defaultKey fetches the account keys and picks one with the configured KeySelector.
+func (c *Client) defaultKey(ctx context.Context) (string, error) {
+	keys := Key{}
+
+	// Fetch the keys, return an error if the fetch fails.
+	if err := c.FetchKeys(ctx, &keys); err != nil {
+		return "", err
 	}
 
-	// Check if the response status is successful.
-	if res.StatusCode != http.StatusOK {
-		return errors.New("bad request")
+	selector := c.credentials.KeySelector
+	if selector == nil {
+		// Without a policy, only an account with a single key has an obvious choice.
+		if len(keys.Chaves) > 1 {
+			return "", fmt.Errorf("account has %d pix keys; set Credentials.KeySelector or the charge Chave", len(keys.Chaves))
+		}
+		selector = FirstKey
 	}
 
-	return nil // Return nil if the keys were fetched successfully.
+	return selector(keys.Chaves)
 }
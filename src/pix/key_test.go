@@ -0,0 +1,51 @@
+package pix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDefaultKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		chaves   []string
+		selector KeySelector
+		want     string
+		err      string
+	}{
+		{"single key", []string{"a"}, nil, "a", ""},
+		{"several keys without selector", []string{"a", "b"}, nil, "", "set Credentials.KeySelector"},
+		{"no keys", nil, nil, "", "no pix keys found"},
+		{"first key opt-in", []string{"a", "b"}, FirstKey, "a", ""},
+		{"preferred key", []string{"a", "b"}, PreferredKey("b"), "b", ""},
+		{"missing preferred key", []string{"a", "b"}, PreferredKey("c"), "", "pix key c not found"},
+	}
+
+	for _, tt := range tests {
+		quoted := make([]string, len(tt.chaves))
+		for i, chave := range tt.chaves {
+			quoted[i] = fmt.Sprintf("%q", chave)
+		}
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/oauth/token" {
+				writeToken(w, "token", 3600)
+				return
+			}
+			fmt.Fprintf(w, `{"chaves":[%s]}`, strings.Join(quoted, ","))
+		}), func(c *Credentials) {
+			c.KeySelector = tt.selector
+		})
+
+		got, err := client.defaultKey(context.Background())
+		switch {
+		case tt.err == "" && (err != nil || got != tt.want):
+			t.Errorf("%s: defaultKey = %q, %v; want %q", tt.name, got, err, tt.want)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s: defaultKey = %q, %v; want an error containing %q", tt.name, got, err, tt.err)
+		}
+	}
+}
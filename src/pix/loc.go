@@ -0,0 +1,242 @@
+package pix
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of charge a location can be created for.
+const (
+	TipoCobCob  = "cob"  // Immediate charge
+	TipoCobCobV = "cobv" // Charge with a due date
+)
+
+// QRCode represents the QR code of a location.
+type QRCode struct {
+	Qrcode           string `json:"qrcode,omitempty"`           // Copy and paste PIX
+	ImagemQrcode     string `json:"imagemQrcode,omitempty"`     // QR code image as a base64 data URI
+	LinkVisualizacao string `json:"linkVisualizacao,omitempty"` // Link to a page showing the QR code
+	Imagem           []byte `json:"-"`                          // Decoded PNG image
+	BadRequest
+}
+
+// Create registers a new location using the default client.
+func (l *Loc) Create() error {
+	return l.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (l *Loc) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateLoc(ctx, l)
+}
+
+// Fetch retrieves the location using the default client.
+func (l *Loc) Fetch() error {
+	return l.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (l *Loc) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchLoc(ctx, l)
+}
+
+// Unlink detaches the location from its charge using the default client.
+func (l *Loc) Unlink() error {
+	return l.UnlinkContext(context.Background())
+}
+
+// UnlinkContext is like Unlink but carries ctx through the request.
+func (l *Loc) UnlinkContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UnlinkLoc(ctx, l)
+}
+
+// QRCode retrieves the QR code of the location using the default client.
+func (l *Loc) QRCode() (*QRCode, error) {
+	return l.QRCodeContext(context.Background())
+}
+
+// QRCodeContext is like QRCode but carries ctx through the request.
+func (l *Loc) QRCodeContext(ctx context.Context) (*QRCode, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.QRCode(ctx, l.ID)
+}
+
+// ListLoc lists the locations matching the filter using the default client.
+func ListLoc(filter LocFilter) (*LocList, error) {
+	return ListLocContext(context.Background(), filter)
+}
+
+// ListLocContext is like ListLoc but carries ctx through the request.
+func ListLocContext(ctx context.Context, filter LocFilter) (*LocList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListLoc(ctx, filter)
+}
+
+// CreateLoc registers a new location for the charge type set in TipoCob.
+func (c *Client) CreateLoc(ctx context.Context, l *Loc) error {
+	// Ensure the type of charge is provided; it is required to create the location.
+	if l.TipoCob == "" {
+		return errors.New("tipoCob is required")
+	}
+
+	// Create a new HTTP POST request with the charge type as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, Loc{TipoCob: l.TipoCob}, "v2", "loc")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Loc object.
+	return c.do(req, l, http.StatusCreated)
+}
+
+// FetchLoc retrieves the details of a location using its ID.
+func (c *Client) FetchLoc(ctx context.Context, l *Loc) error {
+	// Ensure that the ID is provided; it is required to fetch the location.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the location.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "loc", strconv.Itoa(l.ID))
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Loc object.
+	return c.do(req, l, http.StatusOK)
+}
+
+// UnlinkLoc detaches a location from the charge it is linked to.
+func (c *Client) UnlinkLoc(ctx context.Context, l *Loc) error {
+	// Ensure that the ID is provided; it is required to unlink the location.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP DELETE request to unlink the transaction.
+	req, err := c.newRequest(ctx, http.MethodDelete, nil, "v2", "loc", strconv.Itoa(l.ID), "txid")
+	if err != nil {
+		return err
+	}
+
+	// The response no longer carries the TxID, so clear it before unmarshalling.
+	l.TxID = ""
+
+	// Execute the request and unmarshal the response into the Loc object.
+	return c.do(req, l, http.StatusOK)
+}
+
+// QRCode retrieves the QR code of a location, with the image decoded into Imagem.
+func (c *Client) QRCode(ctx context.Context, id int) (*QRCode, error) {
+	// Ensure that the ID is provided; it is required to fetch the QR code.
+	if id == 0 {
+		return nil, errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the QR code.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "loc", strconv.Itoa(id), "qrcode")
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the request and unmarshal the response into the QRCode object.
+	qrcode := &QRCode{}
+	if err := c.do(req, qrcode, http.StatusOK); err != nil {
+		return qrcode, err
+	}
+
+	// Decode the image, dropping the data URI prefix if present.
+	image := qrcode.ImagemQrcode
+	if strings.HasPrefix(image, "data:") {
+		image = image[strings.IndexByte(image, ',')+1:]
+	}
+
+	qrcode.Imagem, err = base64.StdEncoding.DecodeString(image)
+	if err != nil {
+		return qrcode, errors.New("failed to decode qrcode image")
+	}
+
+	return qrcode, nil
+}
+
+// ListLoc lists the locations created within the filter period.
+func (c *Client) ListLoc(ctx context.Context, filter LocFilter) (*LocList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing locations.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "loc")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &LocList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// LocFilter holds the filters for listing locations.
+type LocFilter struct {
+	Inicio       time.Time // Start of the creation period
+	Fim          time.Time // End of the creation period
+	TxIdPresente *bool     // Whether the location is linked to a charge
+	TipoCob      string    // Type of charge
+	Paginacao    Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f LocFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.TxIdPresente != nil {
+		query.Set("txIdPresente", strconv.FormatBool(*f.TxIdPresente))
+	}
+	if f.TipoCob != "" {
+		query.Set("tipoCob", f.TipoCob)
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// LocList represents a page of locations.
+type LocList struct {
+	Parametros Parametros `json:"parametros"`    // Filters and pagination of the page
+	Loc        []Loc      `json:"loc,omitempty"` // Locations in the page
+	BadRequest
+}
@@ -0,0 +1,214 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Statuses of a charge within a batch.
+const (
+	LoteStatusEmProcessamento = "EM_PROCESSAMENTO"
+	LoteStatusCriada          = "CRIADA"
+	LoteStatusNegada          = "NEGADA"
+)
+
+// LoteCobV represents a batch of charges with a due date.
+type LoteCobV struct {
+	ID        int    `json:"id,omitempty"`        // Batch ID chosen by the caller
+	Descricao string `json:"descricao,omitempty"` // Batch description
+	Criacao   string `json:"criacao,omitempty"`   // Timestamp of batch creation
+	CobsV     []CobV `json:"cobsv,omitempty"`     // Charges in the batch
+	BadRequest
+}
+
+// Problema describes why the API rejected a charge.
+type Problema struct {
+	Type      string     `json:"type,omitempty"`      // Problem type URI
+	Title     string     `json:"title,omitempty"`     // Short description
+	Status    int        `json:"status,omitempty"`    // HTTP status of the problem
+	Detail    string     `json:"detail,omitempty"`    // Detailed description
+	Violacoes []Violacao `json:"violacoes,omitempty"` // Field violations
+}
+
+// Violacao represents a field violation within a Problema.
+type Violacao struct {
+	Razao       string `json:"razao,omitempty"`       // Reason for the violation
+	Propriedade string `json:"propriedade,omitempty"` // Offending property
+	Valor       string `json:"valor,omitempty"`       // Offending value
+}
+
+// Create issues the batch of charges using the default client.
+func (l *LoteCobV) Create() error {
+	return l.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (l *LoteCobV) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateLoteCobV(ctx, l)
+}
+
+// Update revises the charges of the batch using the default client.
+func (l *LoteCobV) Update() error {
+	return l.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (l *LoteCobV) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateLoteCobV(ctx, l)
+}
+
+// Fetch retrieves the status of the batch using the default client.
+func (l *LoteCobV) Fetch() error {
+	return l.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (l *LoteCobV) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchLoteCobV(ctx, l)
+}
+
+// ListLoteCobV lists the batches created within the period using the default client.
+func ListLoteCobV(inicio, fim time.Time, paginacao Paginacao) (*LoteCobVList, error) {
+	return ListLoteCobVContext(context.Background(), inicio, fim, paginacao)
+}
+
+// ListLoteCobVContext is like ListLoteCobV but carries ctx through the request.
+func ListLoteCobVContext(ctx context.Context, inicio, fim time.Time, paginacao Paginacao) (*LoteCobVList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListLoteCobV(ctx, inicio, fim, paginacao)
+}
+
+// Criadas returns the charges of the batch that were created.
+func (l *LoteCobV) Criadas() []CobV {
+	return l.withStatus(LoteStatusCriada)
+}
+
+// Negadas returns the charges of the batch that were rejected, with their Problema.
+func (l *LoteCobV) Negadas() []CobV {
+	return l.withStatus(LoteStatusNegada)
+}
+
+// withStatus returns the charges of the batch in the given status.
+func (l *LoteCobV) withStatus(status string) []CobV {
+	var cobs []CobV
+	for _, cv := range l.CobsV {
+		if cv.Status == status {
+			cobs = append(cobs, cv)
+		}
+	}
+	return cobs
+}
+
+// CreateLoteCobV issues a batch of charges with a due date. The API processes
+// the batch asynchronously; use FetchLoteCobV to follow each charge.
+func (c *Client) CreateLoteCobV(ctx context.Context, l *LoteCobV) error {
+	return c.sendLoteCobV(ctx, http.MethodPut, l)
+}
+
+// UpdateLoteCobV revises the charges of an existing batch.
+func (c *Client) UpdateLoteCobV(ctx context.Context, l *LoteCobV) error {
+	return c.sendLoteCobV(ctx, http.MethodPatch, l)
+}
+
+// sendLoteCobV submits the batch with the given method.
+func (c *Client) sendLoteCobV(ctx context.Context, method string, l *LoteCobV) error {
+	// Ensure the batch has an ID and that every charge has its TxID.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+	if len(l.CobsV) == 0 {
+		return errors.New("cobsv is required")
+	}
+	for _, cv := range l.CobsV {
+		if cv.TxID == "" {
+			return errors.New("txid is required for every charge in the batch")
+		}
+	}
+
+	// The ID goes in the path only, and each charge carries only its TxID
+	// and the fields the API accepts.
+	body := LoteCobV{Descricao: l.Descricao, CobsV: make([]CobV, len(l.CobsV))}
+	for i, cv := range l.CobsV {
+		body.CobsV[i] = cv.payload()
+		body.CobsV[i].TxID = cv.TxID
+	}
+
+	// Create a new HTTP request with the batch as the body.
+	req, err := c.newRequest(ctx, method, body, "v2", "lotecobv", strconv.Itoa(l.ID))
+	if err != nil {
+		return err
+	}
+
+	// Execute the request; the batch is accepted for processing.
+	return c.do(req, l, http.StatusAccepted)
+}
+
+// FetchLoteCobV retrieves the batch and the status of each of its charges.
+func (c *Client) FetchLoteCobV(ctx context.Context, l *LoteCobV) error {
+	// Ensure that the ID is provided; it is required to fetch the batch.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the batch.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "lotecobv", strconv.Itoa(l.ID))
+	if err != nil {
+		return err
+	}
+
+	// Drop the submitted charges so they are replaced by their statuses.
+	l.CobsV = nil
+
+	// Execute the request and unmarshal the response into the LoteCobV object.
+	return c.do(req, l, http.StatusOK)
+}
+
+// ListLoteCobV lists the batches created within the period.
+func (c *Client) ListLoteCobV(ctx context.Context, inicio, fim time.Time, paginacao Paginacao) (*LoteCobVList, error) {
+	// Build the query string from the period and pagination.
+	query, err := periodQuery(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	paginacao.setQuery(query)
+
+	// Create a new HTTP GET request for listing batches.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "lotecobv")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &LoteCobVList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// LoteCobVList represents a page of batches.
+type LoteCobVList struct {
+	Parametros Parametros `json:"parametros"`      // Filters and pagination of the page
+	Lotes      []LoteCobV `json:"lotes,omitempty"` // Batches in the page
+	BadRequest
+}
@@ -0,0 +1,61 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSendLoteCobVSendsAcceptedFields(t *testing.T) {
+	// A batch as returned by Fetch, with its rejected charge fixed afterwards.
+	newLote := func() *LoteCobV {
+		return &LoteCobV{
+			ID:        1,
+			Descricao: "Mensalidades",
+			Criacao:   "2026-10-01T10:00:00Z",
+			CobsV: []CobV{{
+				TxID:          "a",
+				Status:        LoteStatusNegada,
+				Revisao:       1,
+				Location:      "pix.example.com/qr/v2/cobv/1",
+				PixCopiaECola: "000201",
+				Chave:         "key",
+				Calendario:    &Calendario{Criacao: "2026-10-01T10:00:00Z", DataDeVencimento: "2026-11-01"},
+				Loc:           &Loc{ID: 1, Location: "pix.example.com/qr/v2/cobv/1"},
+				Recebedor:     &Recebedor{Nome: "Loja"},
+				Valor:         &Valor{Original: "1.00", Final: "0.90"},
+				Problema:      &Problema{Title: "Cobrança inválida"},
+			}},
+			BadRequest: BadRequest{Name: "old"},
+		}
+	}
+
+	for _, send := range []func(*Client, *LoteCobV) error{
+		func(c *Client, l *LoteCobV) error { return c.CreateLoteCobV(context.Background(), l) },
+		func(c *Client, l *LoteCobV) error { return c.UpdateLoteCobV(context.Background(), l) },
+	} {
+		body := sentBody(t, http.StatusAccepted, ``, func(c *Client) error {
+			return send(c, newLote())
+		})
+
+		if got, want := keys(body), []string{"cobsv", "descricao"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("sent fields %v, want %v", got, want)
+		}
+
+		cobsv := body["cobsv"].([]interface{})
+		if got, want := keys(cobsv[0]), []string{"calendario", "chave", "loc", "txid", "valor"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("sent charge fields %v, want %v", got, want)
+		}
+		cob := cobsv[0].(map[string]interface{})
+		if got := keys(cob["calendario"]); !reflect.DeepEqual(got, []string{"dataDeVencimento"}) {
+			t.Errorf("sent calendario fields %v, want [dataDeVencimento]", got)
+		}
+		if got := keys(cob["loc"]); !reflect.DeepEqual(got, []string{"id"}) {
+			t.Errorf("sent loc fields %v, want [id]", got)
+		}
+		if got := keys(cob["valor"]); !reflect.DeepEqual(got, []string{"original"}) {
+			t.Errorf("sent valor fields %v, want [original]", got)
+		}
+	}
+}
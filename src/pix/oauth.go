@@ -1,19 +1,17 @@
 package pix
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
-var Authorization Token
-
 // Token represents the authentication credentials
 type Token struct {
 	AccessToken string `json:"access_token,omitempty"`
@@ -24,88 +22,194 @@ type Token struct {
 	BadRequest
 }
 
-// OAuth performs authentication and returns a Token
+// OAuth performs authentication with the default client and returns a Token
 func OAuth() Token {
-	if Client == nil {
-		return Token{Error: errors.New("client not defined")}
+	return OAuthContext(context.Background())
+}
+
+// OAuthContext is like OAuth but carries ctx through the request.
+func OAuthContext(ctx context.Context) Token {
+	client, err := defaultClient()
+	if err != nil {
+		return Token{Error: err}
 	}
+	return client.OAuth(ctx)
+}
+
+const (
+	// tokenExpiryDelta is how long before its expiry a token stops being used
+	tokenExpiryDelta = 30 * time.Second
+
+	// tokenRefreshWindow is how long before its expiry a token is renewed in the background
+	tokenRefreshWindow = 2 * time.Minute
+
+	// defaultTokenLifetime is assumed when the token reports neither expires_in nor exp
+	defaultTokenLifetime = time.Hour
+)
+
+// tokenCache holds the current token and makes sure a single refresh runs at a time
+type tokenCache struct {
+	mu      sync.Mutex
+	token   Token
+	stale   time.Time // When the token stops being used
+	renew   time.Time // When the token starts being renewed in the background
+	pending *tokenCall
+}
 
-	if token := checkToken(); token != nil {
-		return *token
+// tokenCall is a token request shared by every caller waiting on it
+type tokenCall struct {
+	done  chan struct{}
+	token Token
+}
+
+// OAuth returns a valid Token, requesting a new one when the cached token is about to expire
+func (c *Client) OAuth(ctx context.Context) Token {
+	c.tokens.mu.Lock()
+
+	now := time.Now()
+	if c.tokens.token.AccessToken != "" && now.Before(c.tokens.stale) {
+		token := c.tokens.token
+
+		// Renew the token in the background before it actually expires.
+		if now.After(c.tokens.renew) {
+			c.refreshToken(ctx)
+		}
+
+		c.tokens.mu.Unlock()
+		return token
 	}
 
-	payload := strings.NewReader(`{"grant_type": "client_credentials"}`)
+	call := c.refreshToken(ctx)
+	c.tokens.mu.Unlock()
 
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
-	if err != nil {
-		return Token{Error: fmt.Errorf("failed to load certificates: %v", err)}
+	select {
+	case <-call.done:
+		return call.token
+	case <-ctx.Done():
+		return Token{Error: ctx.Err()}
 	}
+}
 
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
+// refreshToken starts a token request unless one is already in flight.
+// The caller must hold c.tokens.mu.
+func (c *Client) refreshToken(ctx context.Context) *tokenCall {
+	if c.tokens.pending != nil {
+		return c.tokens.pending
 	}
 
-	path, err := url.JoinPath(EFI_BASE_URL, "oauth", "token")
+	call := &tokenCall{done: make(chan struct{})}
+	c.tokens.pending = call
+
+	// The request is shared, so it must not be cancelled with the caller that started it.
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		token, expiry := c.requestToken(ctx)
+
+		c.tokens.mu.Lock()
+		if token.Error == nil {
+			c.tokens.token = token
+			c.tokens.stale, c.tokens.renew = tokenDeadlines(time.Now(), expiry)
+		}
+		c.tokens.pending = nil
+		c.tokens.mu.Unlock()
+
+		call.token = token
+		close(call.done)
+	}()
+
+	return call
+}
+
+// invalidateToken drops the cached token if it still matches the given authorization
+func (c *Client) invalidateToken(authorization string) {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+
+	if c.tokens.token.authorization() == authorization {
+		c.tokens.token = Token{}
+		c.tokens.stale, c.tokens.renew = time.Time{}, time.Time{}
+	}
+}
+
+// requestToken performs authentication and returns a Token with its expiry
+func (c *Client) requestToken(ctx context.Context) (Token, time.Time) {
+	payload := strings.NewReader(`{"grant_type": "client_credentials"}`)
+
+	path, err := url.JoinPath(c.baseURL, "oauth", "token")
 	if err != nil {
-		return Token{Error: fmt.Errorf("failed to construct URL: %v", err)}
+		return Token{Error: fmt.Errorf("failed to construct URL: %v", err)}, time.Time{}
 	}
 
-	req, err := http.NewRequest(http.MethodPost, path, payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, payload)
 	if err != nil {
-		return Token{Error: err}
+		return Token{Error: err}, time.Time{}
 	}
 
-	req.SetBasicAuth(Client.ClientID, Client.ClientSecret)
+	req.SetBasicAuth(c.credentials.ClientID, c.credentials.ClientSecret)
 	req.Header.Add("Content-Type", "application/json")
 
-	res, err := client.Do(req)
+	// Count the lifetime from before the request so the expiry is never late.
+	issued := time.Now()
+
+	res, err := c.http.Do(req)
 	if err != nil {
-		return Token{Error: err}
+		return Token{Error: err}, time.Time{}
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return Token{Error: err}
+		return Token{Error: err}, time.Time{}
 	}
 
 	token := Token{}
 	if err := json.Unmarshal(body, &token); err != nil {
-		return Token{Error: err}
+		return Token{Error: err}, time.Time{}
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return Token{Error: fmt.Errorf("bad request: %s", string(body))}
+		return Token{Error: newAPIError(res.StatusCode, body)}, time.Time{}
 	}
 
-	Authorization = token
-	return token
+	return token, tokenExpiry(token, issued)
 }
 
-// checkToken verifies if the current token is valid
-func checkToken() *Token {
-	if Authorization.AccessToken != "" {
-		token := strings.Split(authorization(), " ")[1]
+// tokenExpiry computes when the token expires from expires_in. The JWT exp
+// claim, when the token has one, is only used to cross-check it. A token
+// reporting neither is assumed to last defaultTokenLifetime
+func tokenExpiry(token Token, issued time.Time) time.Time {
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = issued.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
 
-		claims, err := decodeJWT(token)
-		if err != nil {
-			return &Token{Error: err}
+	if claims, err := decodeJWT(token.AccessToken); err == nil {
+		if exp, ok := claims["exp"].(float64); ok {
+			if claimed := time.Unix(int64(exp), 0); expiry.IsZero() || claimed.Before(expiry) {
+				expiry = claimed
+			}
 		}
+	}
 
-		if exp, ok := claims["exp"].(float64); ok && time.Unix(int64(exp), 0).After(time.Now().Add(30*time.Second)) {
-			return &Authorization
-		}
+	if expiry.IsZero() {
+		expiry = issued.Add(defaultTokenLifetime)
 	}
 
-	return nil
+	return expiry
+}
+
+// tokenDeadlines returns when a token expiring at expiry stops being used and
+// when its background renewal starts. Both margins shrink for short-lived
+// tokens so that they are still reused instead of being renewed on every call
+func tokenDeadlines(now, expiry time.Time) (stale, renew time.Time) {
+	lifetime := expiry.Sub(now)
+	stale = expiry.Add(-min(tokenExpiryDelta, lifetime/4))
+	renew = expiry.Add(-min(tokenRefreshWindow, lifetime/2))
+	return stale, renew
 }
 
-// authorization returns the authorization token in the correct format
-func authorization() string {
-	return fmt.Sprintf("%s %s", Authorization.TokenType, Authorization.AccessToken)
+// authorization returns the token in the format expected by the authorization header
+func (t Token) authorization() string {
+	return fmt.Sprintf("%s %s", t.TokenType, t.AccessToken)
 }
@@ -0,0 +1,227 @@
+package pix
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// redirectTransport sends every request to a test server instead of the Efí API
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestClient returns a client whose requests are served by handler
+func newTestClient(t *testing.T, handler http.Handler, configure ...func(*Credentials)) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := Credentials{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Timeout:      5,
+		Transport:    redirectTransport{target: target},
+	}
+	for _, fn := range configure {
+		fn(&credentials)
+	}
+
+	client, err := NewClient(credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// writeToken answers a token request with the given access token and lifetime
+func writeToken(w http.ResponseWriter, accessToken string, expiresIn int) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":%d}`, accessToken, expiresIn)
+}
+
+func TestOAuthSingleFlight(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		writeToken(w, "token", 3600)
+	}))
+
+	const callers = 32
+	var wg sync.WaitGroup
+	tokens := make([]Token, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i] = client.OAuth(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	for i, token := range tokens {
+		if token.Error != nil || token.AccessToken != "token" {
+			t.Fatalf("caller %d got %+v", i, token)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d callers made %d token requests, want 1", callers, n)
+	}
+}
+
+func TestOAuthBackgroundRefresh(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			writeToken(w, "old", 3600)
+			return
+		}
+		writeToken(w, "new", 3600)
+	}))
+
+	if token := client.OAuth(context.Background()); token.AccessToken != "old" {
+		t.Fatalf("first call got %+v", token)
+	}
+
+	// Move the cached token into its refresh window.
+	client.tokens.mu.Lock()
+	client.tokens.renew = time.Now()
+	client.tokens.mu.Unlock()
+
+	// The cached token is still valid, so it is returned while the renewal runs.
+	if token := client.OAuth(context.Background()); token.AccessToken != "old" {
+		t.Fatalf("second call got %+v, want the cached token", token)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for client.OAuth(context.Background()).AccessToken != "new" {
+		if time.Now().After(deadline) {
+			t.Fatal("token was not renewed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if n := requests.Load(); n != 2 {
+		t.Errorf("made %d token requests, want 2", n)
+	}
+}
+
+func TestOAuthCancelledCaller(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		writeToken(w, "token", 3600)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan Token)
+	go func() { done <- client.OAuth(ctx) }()
+
+	// Wait for the refresh to reach the server before cancelling the caller.
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if token := <-done; !errors.Is(token.Error, context.Canceled) {
+		t.Fatalf("cancelled caller got %+v, want context.Canceled", token)
+	}
+
+	// The shared refresh keeps running and serves the next caller.
+	close(release)
+	if token := client.OAuth(context.Background()); token.Error != nil || token.AccessToken != "token" {
+		t.Fatalf("next caller got %+v", token)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("made %d token requests, want 1", n)
+	}
+}
+
+func TestOAuthReusesTokenWithoutExpiry(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"access_token":"opaque","token_type":"Bearer"}`))
+	}))
+
+	for range 5 {
+		if token := client.OAuth(context.Background()); token.AccessToken != "opaque" {
+			t.Fatalf("got %+v", token)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("5 calls made %d token requests, want 1", n)
+	}
+}
+
+// newTestJWT returns an unsigned JWT carrying the given exp claim
+func newTestJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "e30." + payload + ".signature"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	issued := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name  string
+		token Token
+		want  time.Time
+	}{
+		{"expires_in", Token{AccessToken: "opaque", ExpiresIn: 600}, issued.Add(10 * time.Minute)},
+		{"no expiry", Token{AccessToken: "opaque"}, issued.Add(defaultTokenLifetime)},
+		{"exp only", Token{AccessToken: newTestJWT(issued.Add(time.Minute))}, issued.Add(time.Minute)},
+		{"earlier exp", Token{AccessToken: newTestJWT(issued.Add(time.Minute)), ExpiresIn: 600}, issued.Add(time.Minute)},
+		{"later exp", Token{AccessToken: newTestJWT(issued.Add(time.Hour)), ExpiresIn: 600}, issued.Add(10 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		if got := tokenExpiry(tt.token, issued); !got.Equal(tt.want) {
+			t.Errorf("%s: tokenExpiry = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTokenDeadlines(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	// Long-lived tokens use the fixed margins.
+	stale, renew := tokenDeadlines(now, now.Add(time.Hour))
+	if want := now.Add(time.Hour - tokenExpiryDelta); !stale.Equal(want) {
+		t.Errorf("stale = %v, want %v", stale, want)
+	}
+	if want := now.Add(time.Hour - tokenRefreshWindow); !renew.Equal(want) {
+		t.Errorf("renew = %v, want %v", renew, want)
+	}
+
+	// A token shorter than tokenExpiryDelta is still usable for a while.
+	stale, renew = tokenDeadlines(now, now.Add(20*time.Second))
+	if !stale.After(now) || !renew.After(now) || renew.After(stale) {
+		t.Errorf("20s token: stale = %v, renew = %v, want both after %v with renew first", stale, renew, now)
+	}
+}
@@ -1,16 +1,22 @@
 package pix
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
+// Charge statuses shared by immediate and due-date charges.
+const (
+	StatusAtiva                        = "ATIVA"
+	StatusConcluida                    = "CONCLUIDA"
+	StatusRemovidaPeloUsuarioRecebedor = "REMOVIDA_PELO_USUARIO_RECEBEDOR"
+	StatusRemovidaPeloPSP              = "REMOVIDA_PELO_PSP"
+)
+
 // Pix represents the main structure for a PIX transaction.
 type Pix struct {
 	TipoCob            string           `json:"tipoCob,omitempty"`            // Type of charge
@@ -31,58 +37,85 @@ type Pix struct {
 	BadRequest
 }
 
-// Create initializes and sends a PIX transaction request.
+// Create initializes and sends a PIX transaction request using the default client.
 func (p *Pix) Create() error {
-	// Check if the PIX key is provided; if not, fetch available keys.
-	if p.Chave == "" {
-		keys := Key{}
+	return p.CreateContext(context.Background())
+}
 
-		// Fetch the keys, return an error if the fetch fails.
-		if err := keys.Fetch(); err != nil {
-			return err
-		}
+// CreateContext is like Create but carries ctx through the request.
+func (p *Pix) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreatePix(ctx, p)
+}
 
-		// Ensure at least one key is available.
-		if len(keys.Chaves) == 0 {
-			return errors.New("no pix keys found")
-		}
+// Fetch retrieves the details of a PIX transaction using the default client.
+func (p *Pix) Fetch() error {
+	return p.FetchContext(context.Background())
+}
 
-		// Assign the first available key to the transaction.
-		p.Chave = keys.Chaves[0]
+// FetchContext is like Fetch but carries ctx through the request.
+func (p *Pix) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
 	}
+	return client.FetchPix(ctx, p)
+}
 
-	// Obtain an OAuth token for authentication.
-	token := OAuth()
-	if token.Error != nil {
-		return token.Error
-	}
+// Update revises an existing PIX transaction using the default client.
+func (p *Pix) Update() error {
+	return p.UpdateContext(context.Background())
+}
 
-	// Load the client certificate for secure communication.
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
+// UpdateContext is like Update but carries ctx through the request.
+func (p *Pix) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.UpdatePix(ctx, p)
+}
 
-	// Set up the HTTP client with a timeout and TLS configuration.
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
-	}
+// Cancel removes an active PIX transaction using the default client.
+func (p *Pix) Cancel() error {
+	return p.CancelContext(context.Background())
+}
 
-	// Construct the request path for the PIX transaction.
-	path, err := url.JoinPath(EFI_BASE_URL, "v2", "cob", p.TxID)
+// CancelContext is like Cancel but carries ctx through the request.
+func (p *Pix) CancelContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.CancelPix(ctx, p)
+}
+
+// ListPix lists the PIX transactions matching the filter using the default client.
+func ListPix(filter CobFilter) (*CobList, error) {
+	return ListPixContext(context.Background(), filter)
+}
 
-	// Marshal the Pix object to JSON format.
-	data, err := json.Marshal(p)
+// ListPixContext is like ListPix but carries ctx through the request.
+func ListPixContext(ctx context.Context, filter CobFilter) (*CobList, error) {
+	client, err := defaultClient()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return client.ListPix(ctx, filter)
+}
+
+// CreatePix initializes and sends a PIX transaction request.
+func (c *Client) CreatePix(ctx context.Context, p *Pix) error {
+	// Check if the PIX key is provided; if not, select one of the account keys.
+	if p.Chave == "" {
+		chave, err := c.defaultKey(ctx)
+		if err != nil {
+			return err
+		}
+		p.Chave = chave
 	}
 
 	// Determine the HTTP method: POST for new transactions, PUT for updates.
@@ -91,111 +124,146 @@ func (p *Pix) Create() error {
 		method = http.MethodPut
 	}
 
-	// Create a new HTTP request with the specified method, path, and body.
-	req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+	// Create a new HTTP request with the Pix object as the body.
+	req, err := c.newRequest(ctx, method, p, "v2", "cob", p.TxID)
 	if err != nil {
 		return err
 	}
 
-	// Set the appropriate headers for the request.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("authorization", authorization())
+	// Execute the request and unmarshal the response into the Pix object.
+	return c.do(req, p, http.StatusCreated)
+}
 
-	// Execute the HTTP request.
-	res, err := client.Do(req)
-	if err != nil {
-		return err
+// FetchPix retrieves the details of a PIX transaction using its TxID.
+func (c *Client) FetchPix(ctx context.Context, p *Pix) error {
+	// Ensure that TxID is provided; it is required to fetch the transaction.
+	if p.TxID == "" {
+		return errors.New("txid is required")
 	}
-	defer res.Body.Close() // Ensure the response body is closed after reading.
 
-	// Read the response body.
-	body, err := io.ReadAll(res.Body)
+	// Create a new HTTP GET request for fetching transaction details.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cob", p.TxID)
 	if err != nil {
 		return err
 	}
 
-	// Unmarshal the response body into the Pix object.
-	if err = json.Unmarshal(body, &p); err != nil {
-		return err
-	}
-
-	// Check if the response status is successful.
-	if res.StatusCode != http.StatusCreated {
-		return errors.New("bad request")
-	}
-
-	return nil // Return nil if the transaction was created successfully.
+	// Execute the request and unmarshal the response into the Pix object.
+	return c.do(req, p, http.StatusOK)
 }
 
-// Fetch retrieves the details of a PIX transaction using its TxID.
-func (p *Pix) Fetch() error {
-	// Ensure that TxID is provided; it is required to fetch the transaction.
+// UpdatePix revises an existing PIX transaction using its TxID.
+func (c *Client) UpdatePix(ctx context.Context, p *Pix) error {
+	// Ensure that TxID is provided; it is required to revise the transaction.
 	if p.TxID == "" {
 		return errors.New("txid is required")
 	}
 
-	// Obtain an OAuth token for authentication.
-	token := OAuth()
-	if token.Error != nil {
-		return token.Error
+	// Send only the fields that can be revised.
+	revision := Pix{
+		Devedor:            p.Devedor,
+		Valor:              p.Valor,
+		Chave:              p.Chave,
+		SolicitacaoPagador: p.SolicitacaoPagador,
+		InfoAdicionais:     p.InfoAdicionais,
+	}
+	if p.Calendario != nil && p.Calendario.Expiracao != 0 {
+		revision.Calendario = &Calendario{Expiracao: p.Calendario.Expiracao}
+	}
+	if p.Loc != nil && p.Loc.ID != 0 {
+		revision.Loc = &Loc{ID: p.Loc.ID}
 	}
 
-	// Load the client certificate for secure communication.
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
+	// Create a new HTTP PATCH request with the revised fields as the body.
+	req, err := c.newRequest(ctx, http.MethodPatch, revision, "v2", "cob", p.TxID)
 	if err != nil {
 		return err
 	}
 
-	// Set up the HTTP client with a timeout and TLS configuration.
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
-	}
+	// Execute the request and unmarshal the response into the Pix object.
+	return c.do(req, p, http.StatusOK)
+}
 
-	// Construct the request path for fetching transaction details.
-	path, err := url.JoinPath(EFI_BASE_URL, "v2", "cob", p.TxID)
-	if err != nil {
-		return err
+// CancelPix removes an active PIX transaction so it can no longer be paid.
+func (c *Client) CancelPix(ctx context.Context, p *Pix) error {
+	// Ensure that TxID is provided; it is required to cancel the transaction.
+	if p.TxID == "" {
+		return errors.New("txid is required")
 	}
 
-	// Create a new HTTP GET request.
-	req, err := http.NewRequest(http.MethodGet, path, nil)
+	// Create a new HTTP PATCH request setting the removal status.
+	req, err := c.newRequest(ctx, http.MethodPatch, Pix{Status: StatusRemovidaPeloUsuarioRecebedor}, "v2", "cob", p.TxID)
 	if err != nil {
 		return err
 	}
 
-	// Set the appropriate headers for the request.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("authorization", authorization())
+	// Execute the request and unmarshal the response into the Pix object.
+	return c.do(req, p, http.StatusOK)
+}
 
-	// Execute the HTTP request.
-	res, err := client.Do(req)
+// ListPix lists the PIX transactions created within the filter period.
+func (c *Client) ListPix(ctx context.Context, filter CobFilter) (*CobList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer res.Body.Close() // Ensure the response body is closed after reading.
 
-	// Read the response body.
-	body, err := io.ReadAll(res.Body)
+	// Create a new HTTP GET request for listing transactions.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "cob")
 	if err != nil {
-		return err
+		return nil, err
 	}
+	req.URL.RawQuery = query.Encode()
 
-	// Unmarshal the response body into the Pix object.
-	if err = json.Unmarshal(body, &p); err != nil {
-		return err
+	// Execute the request and unmarshal the response into the list.
+	list := &CobList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
 	}
 
-	// Check if the response status is successful.
-	if res.StatusCode != http.StatusOK {
-		return errors.New("bad request")
+	return list, nil
+}
+
+// CobFilter holds the filters for listing charges.
+type CobFilter struct {
+	Inicio           time.Time // Start of the creation period
+	Fim              time.Time // End of the creation period
+	CPF              string    // Debtor CPF
+	CNPJ             string    // Debtor CNPJ
+	Status           string    // Charge status
+	LocationPresente *bool     // Whether the charge has a location
+	Paginacao        Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f CobFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.CPF != "" {
+		query.Set("cpf", f.CPF)
+	}
+	if f.CNPJ != "" {
+		query.Set("cnpj", f.CNPJ)
+	}
+	if f.Status != "" {
+		query.Set("status", f.Status)
 	}
+	if f.LocationPresente != nil {
+		query.Set("locationPresente", strconv.FormatBool(*f.LocationPresente))
+	}
+	f.Paginacao.setQuery(query)
 
-	return nil // Return nil if the transaction details were fetched successfully.
+	return query, nil
+}
+
+// CobList represents a page of charges.
+type CobList struct {
+	Parametros Parametros `json:"parametros"`     // Filters and pagination of the page
+	Cobs       []Pix      `json:"cobs,omitempty"` // Charges in the page
+	BadRequest
 }
 
 // Calendario contains information about the transaction's calendar.
@@ -211,6 +279,7 @@ type Devedor struct {
 	CPF        string `json:"cpf,omitempty"`        // CPF (individual taxpayer ID)
 	CNPJ       string `json:"cnpj,omitempty"`       // CNPJ (business taxpayer ID)
 	Nome       string `json:"nome,omitempty"`       // Name of the debtor
+	Email      string `json:"email,omitempty"`      // Email address
 	Logradouro string `json:"logradouro,omitempty"` // Address
 	Cidade     string `json:"cidade,omitempty"`     // City
 	UF         string `json:"uf,omitempty"`         // State
@@ -219,10 +288,12 @@ type Devedor struct {
 
 // Valor represents the value details of the transaction.
 type Valor struct {
-	Original string    `json:"original,omitempty"` // Original amount
-	Multa    *Multa    `json:"multa,omitempty"`    // Penalty information
-	Juros    *Juros    `json:"juros,omitempty"`    // Interest information
-	Desconto *Desconto `json:"desconto,omitempty"` // Discount information
+	Original   string      `json:"original,omitempty"`   // Original amount
+	Multa      *Multa      `json:"multa,omitempty"`      // Penalty information
+	Juros      *Juros      `json:"juros,omitempty"`      // Interest information
+	Abatimento *Abatimento `json:"abatimento,omitempty"` // Rebate information
+	Desconto   *Desconto   `json:"desconto,omitempty"`   // Discount information
+	Final      string      `json:"final,omitempty"`      // Amount due after penalties and discounts
 }
 
 // Multa contains information about penalties.
@@ -237,9 +308,16 @@ type Juros struct {
 	ValorPerc  string `json:"valorPerc,omitempty"`  // Interest percentage
 }
 
+// Abatimento contains information about rebates.
+type Abatimento struct {
+	Modalidade int    `json:"modalidade,omitempty"` // Rebate modality
+	ValorPerc  string `json:"valorPerc,omitempty"`  // Rebate amount or percentage
+}
+
 // Desconto contains information about discounts.
 type Desconto struct {
 	Modalidade       int                `json:"modalidade,omitempty"`       // Discount modality
+	ValorPerc        string             `json:"valorPerc,omitempty"`        // Early payment discount amount or percentage
 	DescontoDataFixa []DescontoDataFixa `json:"descontoDataFixa,omitempty"` // Fixed date discounts
 }
 
@@ -260,6 +338,9 @@ type Loc struct {
 	ID       int    `json:"id,omitempty"`       // Location ID
 	Location string `json:"location,omitempty"` // Location string
 	TipoCob  string `json:"tipoCob,omitempty"`  // Type of charge
+	Criacao  string `json:"criacao,omitempty"`  // Timestamp of location creation
+	TxID     string `json:"txid,omitempty"`     // Transaction ID linked to the location
+	BadRequest
 }
 
 // Pagador represents payer's information.
@@ -270,5 +351,18 @@ type Pagador struct {
 
 // Favorecido represents the recipient's information.
 type Favorecido struct {
-	Chave string `json:"chave,omitempty"` // Recipient key
+	Chave         string      `json:"chave,omitempty"`         // Recipient key
+	ContaBanco    *ContaBanco `json:"contaBanco,omitempty"`    // Recipient bank account, used instead of the key
+	Identificacao *Devedor    `json:"identificacao,omitempty"` // Recipient name and document
+}
+
+// ContaBanco represents a bank account that can receive a PIX.
+type ContaBanco struct {
+	Nome        string `json:"nome,omitempty"`        // Account holder name
+	CPF         string `json:"cpf,omitempty"`         // Account holder CPF
+	CNPJ        string `json:"cnpj,omitempty"`        // Account holder CNPJ
+	CodigoBanco string `json:"codigoBanco,omitempty"` // ISPB of the institution
+	Agencia     string `json:"agencia,omitempty"`     // Branch number, without check digit
+	Conta       string `json:"conta,omitempty"`       // Account number, with check digit
+	TipoConta   string `json:"tipoConta,omitempty"`   // Account type
 }
@@ -0,0 +1,76 @@
+package pix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sentBody runs call against a test server answering with status and response,
+// and returns the JSON body it sent to the API
+func sentBody(t *testing.T, status int, response string, call func(*Client) error) map[string]interface{} {
+	t.Helper()
+
+	var body map[string]interface{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+
+	if err := call(client); err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+// keys returns the sorted keys of a JSON object
+func keys(object interface{}) []string {
+	var names []string
+	for name := range object.(map[string]interface{}) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestUpdatePixSendsRevisableFields(t *testing.T) {
+	// A charge as returned by Fetch, with the amount changed afterwards.
+	p := &Pix{
+		TxID:          "abc",
+		Status:        StatusAtiva,
+		Revisao:       1,
+		Location:      "pix.example.com/qr/v2/1",
+		PixCopiaECola: "000201",
+		Chave:         "key",
+		Calendario:    &Calendario{Criacao: "2026-10-01T10:00:00Z", Expiracao: 3600},
+		Loc:           &Loc{ID: 7, Location: "pix.example.com/qr/v2/1", TipoCob: TipoCobCob, Criacao: "2026-10-01T10:00:00Z"},
+		Valor:         &Valor{Original: "20.00"},
+	}
+
+	body := sentBody(t, http.StatusOK, `{"txid":"abc"}`, func(c *Client) error {
+		return c.UpdatePix(context.Background(), p)
+	})
+
+	if got, want := keys(body), []string{"calendario", "chave", "loc", "valor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent fields %v, want %v", got, want)
+	}
+	if got := keys(body["calendario"]); !reflect.DeepEqual(got, []string{"expiracao"}) {
+		t.Errorf("sent calendario fields %v, want [expiracao]", got)
+	}
+	if got := keys(body["loc"]); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("sent loc fields %v, want [id]", got)
+	}
+}
@@ -0,0 +1,174 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PixRecebido represents a PIX payment received by the account.
+type PixRecebido struct {
+	EndToEndID  string      `json:"endToEndId,omitempty"`  // End-to-end ID of the payment
+	TxID        string      `json:"txid,omitempty"`        // Transaction ID of the paid charge
+	Valor       string      `json:"valor,omitempty"`       // Amount received
+	Chave       string      `json:"chave,omitempty"`       // Key that received the payment
+	Horario     string      `json:"horario,omitempty"`     // Timestamp of the payment
+	Pagador     *Devedor    `json:"pagador,omitempty"`     // Payer information
+	InfoPagador string      `json:"infoPagador,omitempty"` // Message from the payer
+	Devolucoes  []Devolucao `json:"devolucoes,omitempty"`  // Refunds of the payment
+	BadRequest
+}
+
+// Horario holds the timestamps of an operation.
+type Horario struct {
+	Solicitacao string `json:"solicitacao,omitempty"` // When the operation was requested
+	Liquidacao  string `json:"liquidacao,omitempty"`  // When the operation was settled
+}
+
+// Fetch retrieves the received PIX using the default client.
+func (p *PixRecebido) Fetch() error {
+	return p.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (p *PixRecebido) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchPixRecebido(ctx, p)
+}
+
+// ListPixRecebido iterates over the received PIX matching the filter using the default client.
+func ListPixRecebido(filter PixRecebidoFilter) iter.Seq2[PixRecebido, error] {
+	return ListPixRecebidoContext(context.Background(), filter)
+}
+
+// ListPixRecebidoContext is like ListPixRecebido but carries ctx through the requests.
+func ListPixRecebidoContext(ctx context.Context, filter PixRecebidoFilter) iter.Seq2[PixRecebido, error] {
+	client, err := defaultClient()
+	if err != nil {
+		return func(yield func(PixRecebido, error) bool) {
+			yield(PixRecebido{}, err)
+		}
+	}
+	return client.ListPixRecebido(ctx, filter)
+}
+
+// FetchPixRecebido retrieves a received PIX using its end-to-end ID.
+func (c *Client) FetchPixRecebido(ctx context.Context, p *PixRecebido) error {
+	// Ensure that the end-to-end ID is provided; it is required to fetch the payment.
+	if p.EndToEndID == "" {
+		return errors.New("endToEndId is required")
+	}
+
+	// Create a new HTTP GET request for fetching the payment.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "pix", p.EndToEndID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the PixRecebido object.
+	return c.do(req, p, http.StatusOK)
+}
+
+// ListPixRecebido iterates over every PIX received within the filter period,
+// fetching the next page only when the previous one has been consumed.
+// Iteration stops after the first error, which is yielded with a zero value.
+func (c *Client) ListPixRecebido(ctx context.Context, filter PixRecebidoFilter) iter.Seq2[PixRecebido, error] {
+	return func(yield func(PixRecebido, error) bool) {
+		for {
+			page, err := c.listPixRecebidoPage(ctx, filter)
+			if err != nil {
+				yield(PixRecebido{}, err)
+				return
+			}
+
+			for _, p := range page.Pix {
+				if !yield(p, nil) {
+					return
+				}
+			}
+
+			// Stop after the last page.
+			paginacao := page.Parametros.Paginacao
+			if len(page.Pix) == 0 || paginacao.PaginaAtual+1 >= paginacao.QuantidadeDePaginas {
+				return
+			}
+			filter.Paginacao.PaginaAtual = paginacao.PaginaAtual + 1
+		}
+	}
+}
+
+// listPixRecebidoPage fetches the page of received PIX selected by the filter.
+func (c *Client) listPixRecebidoPage(ctx context.Context, filter PixRecebidoFilter) (*pixRecebidoList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing payments.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "pix")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the page.
+	page := &pixRecebidoList{}
+	if err := c.do(req, page, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// PixRecebidoFilter holds the filters for listing received PIX.
+type PixRecebidoFilter struct {
+	Inicio            time.Time // Start of the payment period
+	Fim               time.Time // End of the payment period
+	TxID              string    // Transaction ID of the paid charge
+	CPF               string    // Payer CPF
+	CNPJ              string    // Payer CNPJ
+	TxIdPresente      *bool     // Whether the payment has a TxID
+	DevolucaoPresente *bool     // Whether the payment has refunds
+	Paginacao         Paginacao // First page to fetch and the page size
+}
+
+// query builds the query string for the filter.
+func (f PixRecebidoFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.TxID != "" {
+		query.Set("txid", f.TxID)
+	}
+	if f.CPF != "" {
+		query.Set("cpf", f.CPF)
+	}
+	if f.CNPJ != "" {
+		query.Set("cnpj", f.CNPJ)
+	}
+	if f.TxIdPresente != nil {
+		query.Set("txIdPresente", strconv.FormatBool(*f.TxIdPresente))
+	}
+	if f.DevolucaoPresente != nil {
+		query.Set("devolucaoPresente", strconv.FormatBool(*f.DevolucaoPresente))
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// pixRecebidoList represents a page of received PIX.
+type pixRecebidoList struct {
+	Parametros Parametros    `json:"parametros"`    // Filters and pagination of the page
+	Pix        []PixRecebido `json:"pix,omitempty"` // Payments in the page
+}
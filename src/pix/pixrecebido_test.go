@@ -0,0 +1,135 @@
+package pix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPixRecebidoClient serves the given pages of end-to-end IDs, reporting
+// quantidadeDePaginas as total and answering failPage with a server error
+func newPixRecebidoClient(t *testing.T, pages [][]string, total, failPage int) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+		requests.Add(1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("paginacao.paginaAtual"))
+		if page == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var items []string
+		if page < len(pages) {
+			for _, e2e := range pages[page] {
+				items = append(items, fmt.Sprintf(`{"endToEndId":%q}`, e2e))
+			}
+		}
+		fmt.Fprintf(w, `{"parametros":{"paginacao":{"paginaAtual":%d,"quantidadeDePaginas":%d}},"pix":[%s]}`,
+			page, total, strings.Join(items, ","))
+	}))
+
+	return client, &requests
+}
+
+func TestListPixRecebido(t *testing.T) {
+	filter := PixRecebidoFilter{Inicio: time.Now().Add(-time.Hour), Fim: time.Now()}
+	pages := [][]string{{"E1", "E2"}, {"E3", "E4"}, {"E5"}}
+
+	t.Run("all pages", func(t *testing.T) {
+		client, requests := newPixRecebidoClient(t, pages, 3, -1)
+
+		var got []string
+		for p, err := range client.ListPixRecebido(context.Background(), filter) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, p.EndToEndID)
+		}
+
+		if want := []string{"E1", "E2", "E3", "E4", "E5"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if n := requests.Load(); n != 3 {
+			t.Errorf("made %d requests, want 3", n)
+		}
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		client, requests := newPixRecebidoClient(t, pages, 3, -1)
+
+		var got []string
+		for p, err := range client.ListPixRecebido(context.Background(), filter) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, p.EndToEndID)
+			if len(got) == 3 {
+				break
+			}
+		}
+
+		if want := []string{"E1", "E2", "E3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("made %d requests, want 2", n)
+		}
+	})
+
+	t.Run("failure on page 2", func(t *testing.T) {
+		client, requests := newPixRecebidoClient(t, pages, 3, 1)
+
+		var got []string
+		var errs []error
+		for p, err := range client.ListPixRecebido(context.Background(), filter) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			got = append(got, p.EndToEndID)
+		}
+
+		if want := []string{"E1", "E2"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if len(errs) != 1 || !hasStatus(errs[0], http.StatusInternalServerError) {
+			t.Errorf("errors = %v, want a single 500", errs)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("made %d requests, want 2", n)
+		}
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		// The API reports more pages than it actually serves.
+		client, requests := newPixRecebidoClient(t, pages[:1], 5, -1)
+
+		var got []string
+		for p, err := range client.ListPixRecebido(context.Background(), filter) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, p.EndToEndID)
+		}
+
+		if want := []string{"E1", "E2"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("made %d requests, want 2", n)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Kinds of QR code returned by QRCodeDetalhe.Tipo.
+const (
+	QRCodeEstatico = "estatico" // Static QR code
+	QRCodeDinamico = "dinamico" // Immediate charge
+	QRCodeCobV     = "cobv"     // Charge with a due date
+)
+
+// QRCodeDetalhe represents the decoded contents of a PIX QR code.
+type QRCodeDetalhe struct {
+	TipoCob            string           `json:"tipoCob,omitempty"`            // Type of charge, empty for static QR codes
+	TxID               string           `json:"txid,omitempty"`               // Transaction ID
+	Revisao            int              `json:"revisao,omitempty"`            // Revision number
+	Calendario         *Calendario      `json:"calendario,omitempty"`         // Calendar information
+	Devedor            *Devedor         `json:"devedor,omitempty"`            // Debtor information
+	Recebedor          *Recebedor       `json:"recebedor,omitempty"`          // Receiver information
+	Valor              *Valor           `json:"valor,omitempty"`              // Amount to be paid
+	Chave              string           `json:"chave,omitempty"`              // Receiver key
+	Status             string           `json:"status,omitempty"`             // Charge status
+	SolicitacaoPagador string           `json:"solicitacaoPagador,omitempty"` // Receiver's request
+	InfoAdicionais     *[]InfoAdicional `json:"infoAdicionais,omitempty"`     // Additional information
+	BadRequest
+}
+
+// Tipo reports whether the QR code is static, dynamic or a charge with a due date.
+func (d *QRCodeDetalhe) Tipo() string {
+	switch d.TipoCob {
+	case TipoCobCobV:
+		return QRCodeCobV
+	case TipoCobCob:
+		return QRCodeDinamico
+	default:
+		return QRCodeEstatico
+	}
+}
+
+// PayQRCode pays the QR code in PixCopiaECola using the default client.
+func (e *Envio) PayQRCode() error {
+	return e.PayQRCodeContext(context.Background())
+}
+
+// PayQRCodeContext is like PayQRCode but carries ctx through the request.
+func (e *Envio) PayQRCodeContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.PayQRCode(ctx, e)
+}
+
+// DetalharQRCode decodes a PIX QR code using the default client.
+func DetalharQRCode(pixCopiaECola string) (*QRCodeDetalhe, error) {
+	return DetalharQRCodeContext(context.Background(), pixCopiaECola)
+}
+
+// DetalharQRCodeContext is like DetalharQRCode but carries ctx through the request.
+func DetalharQRCodeContext(ctx context.Context, pixCopiaECola string) (*QRCodeDetalhe, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.DetalharQRCode(ctx, pixCopiaECola)
+}
+
+// PayQRCode pays the QR code in PixCopiaECola from the key in Pagador.
+// The caller-supplied IDEnvio identifies the payment, so repeating the call never pays twice.
+func (c *Client) PayQRCode(ctx context.Context, e *Envio) error {
+	// Ensure the send ID, the payer key and the QR code are provided.
+	if e.IDEnvio == "" {
+		return errors.New("idEnvio is required")
+	}
+	if e.Pagador == nil || e.Pagador.Chave == "" {
+		return errors.New("pagador.chave is required")
+	}
+	if e.PixCopiaECola == "" {
+		return errors.New("pixCopiaECola is required")
+	}
+
+	// Send only the fields accepted by the API.
+	body := Envio{Pagador: e.Pagador, PixCopiaECola: e.PixCopiaECola}
+
+	// Create a new HTTP PUT request with the payment as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, body, "v2", "gn", "pix", e.IDEnvio, "qrcode")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Envio object.
+	if err := c.do(req, e, http.StatusCreated); err != nil {
+		return err
+	}
+
+	if e.EndToEndID == "" {
+		e.EndToEndID = e.E2EID
+	}
+
+	return nil
+}
+
+// DetalharQRCode decodes a PIX QR code, returning the amount, receiver and
+// type of charge it will pay, without paying it.
+func (c *Client) DetalharQRCode(ctx context.Context, pixCopiaECola string) (*QRCodeDetalhe, error) {
+	// Ensure the QR code is provided.
+	if pixCopiaECola == "" {
+		return nil, errors.New("pixCopiaECola is required")
+	}
+
+	// Create a new HTTP POST request with the QR code as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, Envio{PixCopiaECola: pixCopiaECola}, "v2", "gn", "qrcodes", "detalhar")
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the request and unmarshal the response into the details.
+	detalhe := &QRCodeDetalhe{}
+	if err := c.do(req, detalhe, http.StatusOK); err != nil {
+		return detalhe, err
+	}
+
+	return detalhe, nil
+}
@@ -0,0 +1,600 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Statuses of a recurrence.
+const (
+	RecCriada    = "CRIADA"
+	RecAprovada  = "APROVADA"
+	RecRejeitada = "REJEITADA"
+	RecExpirada  = "EXPIRADA"
+	RecCancelada = "CANCELADA"
+)
+
+// Statuses of a recurrence request.
+const (
+	SolicRecCriada    = "CRIADA"
+	SolicRecEnviada   = "ENVIADA"
+	SolicRecRecebida  = "RECEBIDA"
+	SolicRecRejeitada = "REJEITADA"
+	SolicRecAceita    = "ACEITA"
+	SolicRecExpirada  = "EXPIRADA"
+	SolicRecCancelada = "CANCELADA"
+)
+
+// Periodicities of a recurrence.
+const (
+	PeriodicidadeSemanal    = "SEMANAL"
+	PeriodicidadeMensal     = "MENSAL"
+	PeriodicidadeTrimestral = "TRIMESTRAL"
+	PeriodicidadeSemestral  = "SEMESTRAL"
+	PeriodicidadeAnual      = "ANUAL"
+)
+
+// Retry policies of a recurrence.
+const (
+	RetentativaNaoPermite  = "NAO_PERMITE"   // Failed charges are not retried
+	RetentativaPermite3R7D = "PERMITE_3R_7D" // Up to three retries within seven days
+)
+
+// Rec represents a Pix Automático recurrence.
+type Rec struct {
+	IDRec               string         `json:"idRec,omitempty"`               // Recurrence ID
+	Vinculo             *RecVinculo    `json:"vinculo,omitempty"`             // Contract bound to the recurrence
+	Calendario          *RecCalendario `json:"calendario,omitempty"`          // Start, end and periodicity
+	Valor               *RecValor      `json:"valor,omitempty"`               // Amount of each charge
+	PoliticaRetentativa string         `json:"politicaRetentativa,omitempty"` // Retry policy
+	Loc                 *LocRec        `json:"loc,omitempty"`                 // Location information
+	Recebedor           *Recebedor     `json:"recebedor,omitempty"`           // Receiver information
+	Pagador             *RecPagador    `json:"pagador,omitempty"`             // Payer account information
+	Status              string         `json:"status,omitempty"`              // Recurrence status
+	Atualizacao         []Atualizacao  `json:"atualizacao,omitempty"`         // Status history
+	BadRequest
+}
+
+// RecVinculo represents the contract a recurrence charges for.
+type RecVinculo struct {
+	Objeto   string   `json:"objeto,omitempty"`   // What is being charged
+	Contrato string   `json:"contrato,omitempty"` // Contract identifier
+	Devedor  *Devedor `json:"devedor,omitempty"`  // Debtor information
+}
+
+// RecCalendario represents the schedule of a recurrence.
+type RecCalendario struct {
+	DataInicial   string `json:"dataInicial,omitempty"`   // First charge date
+	DataFinal     string `json:"dataFinal,omitempty"`     // Last charge date
+	Periodicidade string `json:"periodicidade,omitempty"` // Charge periodicity
+}
+
+// RecValor represents the amount of a recurrence.
+type RecValor struct {
+	ValorRec             string `json:"valorRec,omitempty"`             // Fixed amount of each charge
+	ValorMinimoRecebedor string `json:"valorMinimoRecebedor,omitempty"` // Minimum amount when it varies
+}
+
+// RecPagador represents the account that pays a recurrence.
+type RecPagador struct {
+	CPF              string `json:"cpf,omitempty"`              // Payer CPF
+	CNPJ             string `json:"cnpj,omitempty"`             // Payer CNPJ
+	ISPBParticipante string `json:"ispbParticipante,omitempty"` // ISPB of the payer institution
+	CodMun           string `json:"codMun,omitempty"`           // IBGE city code of the payer
+}
+
+// Atualizacao represents a status change.
+type Atualizacao struct {
+	Status string `json:"status,omitempty"` // New status
+	Data   string `json:"data,omitempty"`   // Timestamp of the change
+}
+
+// SolicRec represents a request sent to the payer to approve a recurrence.
+type SolicRec struct {
+	IDSolicRec   string              `json:"idSolicRec,omitempty"`   // Request ID
+	IDRec        string              `json:"idRec,omitempty"`        // Recurrence ID
+	Calendario   *SolicRecCalendario `json:"calendario,omitempty"`   // Request expiration
+	Destinatario *Destinatario       `json:"destinatario,omitempty"` // Payer account receiving the request
+	Status       string              `json:"status,omitempty"`       // Request status
+	Atualizacao  []Atualizacao       `json:"atualizacao,omitempty"`  // Status history
+	BadRequest
+}
+
+// SolicRecCalendario represents the expiration of a recurrence request.
+type SolicRecCalendario struct {
+	DataExpiracaoSolicitacao string `json:"dataExpiracaoSolicitacao,omitempty"` // When the request expires
+}
+
+// Destinatario represents the payer account receiving a recurrence request.
+type Destinatario struct {
+	CPF              string `json:"cpf,omitempty"`              // Payer CPF
+	CNPJ             string `json:"cnpj,omitempty"`             // Payer CNPJ
+	Conta            string `json:"conta,omitempty"`            // Account number
+	Agencia          string `json:"agencia,omitempty"`          // Branch number
+	ISPBParticipante string `json:"ispbParticipante,omitempty"` // ISPB of the payer institution
+}
+
+// LocRec represents the location of a recurrence.
+type LocRec struct {
+	ID       int    `json:"id,omitempty"`       // Location ID
+	Location string `json:"location,omitempty"` // Location string
+	Criacao  string `json:"criacao,omitempty"`  // Timestamp of location creation
+	IDRec    string `json:"idRec,omitempty"`    // Recurrence linked to the location
+	BadRequest
+}
+
+// Create registers the recurrence using the default client.
+func (r *Rec) Create() error {
+	return r.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (r *Rec) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateRec(ctx, r)
+}
+
+// Fetch retrieves the recurrence using the default client.
+func (r *Rec) Fetch() error {
+	return r.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (r *Rec) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchRec(ctx, r)
+}
+
+// Update revises the recurrence using the default client.
+func (r *Rec) Update() error {
+	return r.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (r *Rec) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateRec(ctx, r)
+}
+
+// Cancel cancels the recurrence using the default client.
+func (r *Rec) Cancel() error {
+	return r.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel but carries ctx through the request.
+func (r *Rec) CancelContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CancelRec(ctx, r)
+}
+
+// ListRec lists the recurrences matching the filter using the default client.
+func ListRec(filter RecFilter) (*RecList, error) {
+	return ListRecContext(context.Background(), filter)
+}
+
+// ListRecContext is like ListRec but carries ctx through the request.
+func ListRecContext(ctx context.Context, filter RecFilter) (*RecList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListRec(ctx, filter)
+}
+
+// Create sends the recurrence request using the default client.
+func (s *SolicRec) Create() error {
+	return s.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (s *SolicRec) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateSolicRec(ctx, s)
+}
+
+// Fetch retrieves the recurrence request using the default client.
+func (s *SolicRec) Fetch() error {
+	return s.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (s *SolicRec) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchSolicRec(ctx, s)
+}
+
+// Cancel cancels the recurrence request using the default client.
+func (s *SolicRec) Cancel() error {
+	return s.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel but carries ctx through the request.
+func (s *SolicRec) CancelContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CancelSolicRec(ctx, s)
+}
+
+// Create registers a new recurrence location using the default client.
+func (l *LocRec) Create() error {
+	return l.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (l *LocRec) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateLocRec(ctx, l)
+}
+
+// Fetch retrieves the recurrence location using the default client.
+func (l *LocRec) Fetch() error {
+	return l.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (l *LocRec) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchLocRec(ctx, l)
+}
+
+// Unlink detaches the location from its recurrence using the default client.
+func (l *LocRec) Unlink() error {
+	return l.UnlinkContext(context.Background())
+}
+
+// UnlinkContext is like Unlink but carries ctx through the request.
+func (l *LocRec) UnlinkContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UnlinkLocRec(ctx, l)
+}
+
+// ListLocRec lists the recurrence locations matching the filter using the default client.
+func ListLocRec(filter LocRecFilter) (*LocRecList, error) {
+	return ListLocRecContext(context.Background(), filter)
+}
+
+// ListLocRecContext is like ListLocRec but carries ctx through the request.
+func ListLocRecContext(ctx context.Context, filter LocRecFilter) (*LocRecList, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListLocRec(ctx, filter)
+}
+
+// CreateRec registers a new recurrence; the API assigns its IDRec.
+func (c *Client) CreateRec(ctx context.Context, r *Rec) error {
+	// Create a new HTTP POST request with the recurrence as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, r, "v2", "rec")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Rec object.
+	return c.do(req, r, http.StatusCreated)
+}
+
+// FetchRec retrieves the details of a recurrence using its IDRec.
+func (c *Client) FetchRec(ctx context.Context, r *Rec) error {
+	// Ensure that IDRec is provided; it is required to fetch the recurrence.
+	if r.IDRec == "" {
+		return errors.New("idRec is required")
+	}
+
+	// Create a new HTTP GET request for fetching the recurrence.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "rec", r.IDRec)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Rec object.
+	return c.do(req, r, http.StatusOK)
+}
+
+// UpdateRec revises an existing recurrence using its IDRec.
+func (c *Client) UpdateRec(ctx context.Context, r *Rec) error {
+	// Ensure that IDRec is provided; it is required to revise the recurrence.
+	if r.IDRec == "" {
+		return errors.New("idRec is required")
+	}
+
+	// Send only the fields that can be revised: the location, the debtor's
+	// name and the date of the first charge.
+	revision := Rec{}
+	if r.Loc != nil && r.Loc.ID != 0 {
+		revision.Loc = &LocRec{ID: r.Loc.ID}
+	}
+	if r.Vinculo != nil && r.Vinculo.Devedor != nil && r.Vinculo.Devedor.Nome != "" {
+		revision.Vinculo = &RecVinculo{Devedor: &Devedor{Nome: r.Vinculo.Devedor.Nome}}
+	}
+	if r.Calendario != nil && r.Calendario.DataInicial != "" {
+		revision.Calendario = &RecCalendario{DataInicial: r.Calendario.DataInicial}
+	}
+
+	// Create a new HTTP PATCH request with the revised fields as the body.
+	req, err := c.newRequest(ctx, http.MethodPatch, revision, "v2", "rec", r.IDRec)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Rec object.
+	return c.do(req, r, http.StatusOK)
+}
+
+// CancelRec cancels a recurrence so no further charges are issued.
+func (c *Client) CancelRec(ctx context.Context, r *Rec) error {
+	// Ensure that IDRec is provided; it is required to cancel the recurrence.
+	if r.IDRec == "" {
+		return errors.New("idRec is required")
+	}
+
+	// Create a new HTTP PATCH request setting the cancelled status.
+	req, err := c.newRequest(ctx, http.MethodPatch, Rec{Status: RecCancelada}, "v2", "rec", r.IDRec)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Rec object.
+	return c.do(req, r, http.StatusOK)
+}
+
+// ListRec lists the recurrences created within the filter period.
+func (c *Client) ListRec(ctx context.Context, filter RecFilter) (*RecList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing recurrences.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "rec")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &RecList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// CreateSolicRec sends a request to the payer to approve a recurrence.
+func (c *Client) CreateSolicRec(ctx context.Context, s *SolicRec) error {
+	// Ensure that IDRec is provided; it is required to request the approval.
+	if s.IDRec == "" {
+		return errors.New("idRec is required")
+	}
+
+	// Create a new HTTP POST request with the request as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, s, "v2", "solicrec")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SolicRec object.
+	return c.do(req, s, http.StatusCreated)
+}
+
+// FetchSolicRec retrieves the details of a recurrence request using its IDSolicRec.
+func (c *Client) FetchSolicRec(ctx context.Context, s *SolicRec) error {
+	// Ensure that IDSolicRec is provided; it is required to fetch the request.
+	if s.IDSolicRec == "" {
+		return errors.New("idSolicRec is required")
+	}
+
+	// Create a new HTTP GET request for fetching the request.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "solicrec", s.IDSolicRec)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SolicRec object.
+	return c.do(req, s, http.StatusOK)
+}
+
+// CancelSolicRec cancels a recurrence request the payer has not answered yet.
+func (c *Client) CancelSolicRec(ctx context.Context, s *SolicRec) error {
+	// Ensure that IDSolicRec is provided; it is required to cancel the request.
+	if s.IDSolicRec == "" {
+		return errors.New("idSolicRec is required")
+	}
+
+	// Create a new HTTP PATCH request setting the cancelled status.
+	req, err := c.newRequest(ctx, http.MethodPatch, SolicRec{Status: SolicRecCancelada}, "v2", "solicrec", s.IDSolicRec)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SolicRec object.
+	return c.do(req, s, http.StatusOK)
+}
+
+// CreateLocRec registers a new location for a recurrence.
+func (c *Client) CreateLocRec(ctx context.Context, l *LocRec) error {
+	// Create a new HTTP POST request for creating the location.
+	req, err := c.newRequest(ctx, http.MethodPost, nil, "v2", "locrec")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the LocRec object.
+	return c.do(req, l, http.StatusCreated)
+}
+
+// FetchLocRec retrieves the details of a recurrence location using its ID.
+func (c *Client) FetchLocRec(ctx context.Context, l *LocRec) error {
+	// Ensure that the ID is provided; it is required to fetch the location.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the location.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "locrec", strconv.Itoa(l.ID))
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the LocRec object.
+	return c.do(req, l, http.StatusOK)
+}
+
+// UnlinkLocRec detaches a location from the recurrence it is linked to.
+func (c *Client) UnlinkLocRec(ctx context.Context, l *LocRec) error {
+	// Ensure that the ID is provided; it is required to unlink the location.
+	if l.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP DELETE request to unlink the recurrence.
+	req, err := c.newRequest(ctx, http.MethodDelete, nil, "v2", "locrec", strconv.Itoa(l.ID), "idRec")
+	if err != nil {
+		return err
+	}
+
+	// The response no longer carries the IDRec, so clear it before unmarshalling.
+	l.IDRec = ""
+
+	// Execute the request and unmarshal the response into the LocRec object.
+	return c.do(req, l, http.StatusOK)
+}
+
+// ListLocRec lists the recurrence locations created within the filter period.
+func (c *Client) ListLocRec(ctx context.Context, filter LocRecFilter) (*LocRecList, error) {
+	// Build the query string from the filter.
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new HTTP GET request for listing locations.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "locrec")
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	// Execute the request and unmarshal the response into the list.
+	list := &LocRecList{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// RecFilter holds the filters for listing recurrences.
+type RecFilter struct {
+	Inicio           time.Time // Start of the creation period
+	Fim              time.Time // End of the creation period
+	CPF              string    // Debtor CPF
+	CNPJ             string    // Debtor CNPJ
+	Status           string    // Recurrence status
+	Convenio         string    // Agreement identifier
+	LocationPresente *bool     // Whether the recurrence has a location
+	Paginacao        Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f RecFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.CPF != "" {
+		query.Set("cpf", f.CPF)
+	}
+	if f.CNPJ != "" {
+		query.Set("cnpj", f.CNPJ)
+	}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	if f.Convenio != "" {
+		query.Set("convenio", f.Convenio)
+	}
+	if f.LocationPresente != nil {
+		query.Set("locationPresente", strconv.FormatBool(*f.LocationPresente))
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// RecList represents a page of recurrences.
+type RecList struct {
+	Parametros Parametros `json:"parametros"`     // Filters and pagination of the page
+	Recs       []Rec      `json:"recs,omitempty"` // Recurrences in the page
+	BadRequest
+}
+
+// LocRecFilter holds the filters for listing recurrence locations.
+type LocRecFilter struct {
+	Inicio        time.Time // Start of the creation period
+	Fim           time.Time // End of the creation period
+	IDRecPresente *bool     // Whether the location is linked to a recurrence
+	Convenio      string    // Agreement identifier
+	Paginacao     Paginacao // Page to fetch and its size
+}
+
+// query builds the query string for the filter.
+func (f LocRecFilter) query() (url.Values, error) {
+	query, err := periodQuery(f.Inicio, f.Fim)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.IDRecPresente != nil {
+		query.Set("idRecPresente", strconv.FormatBool(*f.IDRecPresente))
+	}
+	if f.Convenio != "" {
+		query.Set("convenio", f.Convenio)
+	}
+	f.Paginacao.setQuery(query)
+
+	return query, nil
+}
+
+// LocRecList represents a page of recurrence locations.
+type LocRecList struct {
+	Parametros Parametros `json:"parametros"`    // Filters and pagination of the page
+	Loc        []LocRec   `json:"loc,omitempty"` // Locations in the page
+	BadRequest
+}
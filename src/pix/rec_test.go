@@ -0,0 +1,39 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateRecSendsRevisableFields(t *testing.T) {
+	// A recurrence as returned by Fetch, with its first charge moved afterwards.
+	r := &Rec{
+		IDRec:               "RR1",
+		Status:              RecAprovada,
+		PoliticaRetentativa: RetentativaNaoPermite,
+		Vinculo:             &RecVinculo{Objeto: "Plano", Contrato: "c1", Devedor: &Devedor{CPF: "12345678909", Nome: "Fulano"}},
+		Calendario:          &RecCalendario{DataInicial: "2026-11-01", Periodicidade: PeriodicidadeMensal},
+		Valor:               &RecValor{ValorRec: "35.00"},
+		Loc:                 &LocRec{ID: 3, Location: "pix.example.com/qr/v2/rec/1", IDRec: "RR1"},
+		Atualizacao:         []Atualizacao{{Status: RecCriada, Data: "2026-10-01T10:00:00Z"}},
+	}
+
+	body := sentBody(t, http.StatusOK, `{"idRec":"RR1"}`, func(c *Client) error {
+		return c.UpdateRec(context.Background(), r)
+	})
+
+	if got, want := keys(body), []string{"calendario", "loc", "vinculo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent fields %v, want %v", got, want)
+	}
+	if got := keys(body["calendario"]); !reflect.DeepEqual(got, []string{"dataInicial"}) {
+		t.Errorf("sent calendario fields %v, want [dataInicial]", got)
+	}
+	if got := keys(body["loc"]); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("sent loc fields %v, want [id]", got)
+	}
+	if got := keys(body["vinculo"]); !reflect.DeepEqual(got, []string{"devedor"}) {
+		t.Errorf("sent vinculo fields %v, want [devedor]", got)
+	}
+}
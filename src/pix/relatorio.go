@@ -0,0 +1,342 @@
+package pix
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Statuses of a report.
+const (
+	RelatorioEmProcessamento = "EM_PROCESSAMENTO"
+	RelatorioConcluido       = "CONCLUIDO"
+	RelatorioErro            = "ERRO"
+)
+
+// Relatorio represents a reconciliation statement of a single day, generated
+// asynchronously by Efí.
+type Relatorio struct {
+	ID            string         `json:"id,omitempty"`            // Report ID
+	DataMovimento string         `json:"dataMovimento,omitempty"` // Day covered, as YYYY-MM-DD
+	TipoRegistros *TipoRegistros `json:"tipoRegistros,omitempty"` // Entries included; every type when nil
+	Status        string         `json:"status,omitempty"`        // Report status
+	Arquivo       []byte         `json:"-"`                       // CSV file, once the report is ready
+	BadRequest
+}
+
+// TipoRegistros selects the entries included in a report.
+type TipoRegistros struct {
+	PixRecebido              bool `json:"pixRecebido"`              // Received PIX
+	PixDevolucaoEnviada      bool `json:"pixDevolucaoEnviada"`      // Refunds of received PIX
+	TarifaPixRecebido        bool `json:"tarifaPixRecebido"`        // Fees charged on received PIX
+	PixEnviadoChave          bool `json:"pixEnviadoChave"`          // PIX sent to a key
+	PixEnviadoDadosBancarios bool `json:"pixEnviadoDadosBancarios"` // PIX sent to bank account details
+	PixDevolucaoRecebida     bool `json:"pixDevolucaoRecebida"`     // Refunds of sent PIX
+}
+
+// RelatorioLinha represents a single entry of a statement report.
+type RelatorioLinha struct {
+	Tipo       string    // Entry type
+	EndToEndID string    // End-to-end ID of the payment
+	TxID       string    // Transaction ID of the charge, if any
+	Valor      int64     // Amount of the entry, in cents
+	Tarifa     int64     // Fees charged on the entry, in cents
+	Data       time.Time // Timestamp of the entry
+}
+
+// Final reports whether the report reached a status that will not change anymore.
+func (r *Relatorio) Final() bool {
+	return r.Status == RelatorioConcluido || r.Status == RelatorioErro
+}
+
+// Create requests the report using the default client.
+func (r *Relatorio) Create() error {
+	return r.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (r *Relatorio) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateRelatorio(ctx, r)
+}
+
+// Fetch retrieves the report status, or its file once ready, using the default client.
+func (r *Relatorio) Fetch() error {
+	return r.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (r *Relatorio) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchRelatorio(ctx, r)
+}
+
+// Wait polls the report using the default client until it is ready.
+func (r *Relatorio) Wait(interval, timeout time.Duration) error {
+	return r.WaitContext(context.Background(), interval, timeout)
+}
+
+// WaitContext is like Wait but carries ctx through the request.
+func (r *Relatorio) WaitContext(ctx context.Context, interval, timeout time.Duration) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.WaitRelatorio(ctx, r, interval, timeout)
+}
+
+// Download retrieves and parses the report CSV using the default client.
+func (r *Relatorio) Download() ([]RelatorioLinha, error) {
+	return r.DownloadContext(context.Background())
+}
+
+// DownloadContext is like Download but carries ctx through the request.
+func (r *Relatorio) DownloadContext(ctx context.Context) ([]RelatorioLinha, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.DownloadRelatorio(ctx, r)
+}
+
+// Generate requests the report, waits for it and downloads it using the default client.
+func (r *Relatorio) Generate(interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	return r.GenerateContext(context.Background(), interval, timeout)
+}
+
+// GenerateContext is like Generate but carries ctx through the request.
+func (r *Relatorio) GenerateContext(ctx context.Context, interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateRelatorio(ctx, r, interval, timeout)
+}
+
+// GenerateRelatorios generates the reports of every day from inicio to fim using the default client.
+func GenerateRelatorios(inicio, fim time.Time, tipos *TipoRegistros, interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	return GenerateRelatoriosContext(context.Background(), inicio, fim, tipos, interval, timeout)
+}
+
+// GenerateRelatoriosContext is like GenerateRelatorios but carries ctx through the request.
+func GenerateRelatoriosContext(ctx context.Context, inicio, fim time.Time, tipos *TipoRegistros, interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	client, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.GenerateRelatorios(ctx, inicio, fim, tipos, interval, timeout)
+}
+
+// CreateRelatorio requests the statement of DataMovimento; the API assigns its ID.
+func (c *Client) CreateRelatorio(ctx context.Context, r *Relatorio) error {
+	// Ensure the day is provided.
+	if r.DataMovimento == "" {
+		return errors.New("dataMovimento is required")
+	}
+
+	// Send only the day and the entry types.
+	body := Relatorio{DataMovimento: r.DataMovimento, TipoRegistros: r.TipoRegistros}
+
+	// Create a new HTTP POST request with the day as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, body, "v2", "gn", "relatorios", "extrato-conciliacao")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the Relatorio object.
+	return c.do(req, r, http.StatusCreated)
+}
+
+// FetchRelatorio retrieves a report using its ID. While the report is being
+// generated the API answers with its status; once ready it serves the CSV
+// itself, which is kept in Arquivo.
+func (c *Client) FetchRelatorio(ctx context.Context, r *Relatorio) error {
+	// Ensure that the ID is provided; it is required to fetch the report.
+	if r.ID == "" {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the report.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "relatorios", r.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request; the body is either the status or the file.
+	res, body, err := c.authorized(req)
+	if err != nil {
+		return err
+	}
+
+	// A finished report is served as the CSV file.
+	if res.StatusCode == http.StatusOK && !strings.Contains(res.Header.Get("Content-Type"), "json") {
+		r.Status = RelatorioConcluido
+		r.Arquivo = body
+		return nil
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		return newAPIError(res.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, r)
+}
+
+// WaitRelatorio polls the report every interval until it is ready or the
+// timeout expires. A report that failed to generate is returned as an error.
+func (c *Client) WaitRelatorio(ctx context.Context, r *Relatorio, interval, timeout time.Duration) error {
+	err := poll(ctx, interval, timeout, func(ctx context.Context) (bool, error) {
+		if err := c.FetchRelatorio(ctx, r); err != nil {
+			return false, err
+		}
+		if r.Status == RelatorioErro {
+			return false, fmt.Errorf("report %s failed to generate", r.ID)
+		}
+		return r.Status == RelatorioConcluido, nil
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return fmt.Errorf("report %s still %s: %w", r.ID, r.Status, err)
+	}
+	return err
+}
+
+// DownloadRelatorio parses the entries of a finished report, fetching its
+// file unless a previous Fetch or Wait already did.
+func (c *Client) DownloadRelatorio(ctx context.Context, r *Relatorio) ([]RelatorioLinha, error) {
+	if r.Arquivo == nil {
+		if err := c.FetchRelatorio(ctx, r); err != nil {
+			return nil, err
+		}
+		if r.Arquivo == nil {
+			return nil, fmt.Errorf("report %s is %s, not ready", r.ID, r.Status)
+		}
+	}
+
+	return parseRelatorio(bytes.NewReader(r.Arquivo))
+}
+
+// GenerateRelatorio requests the report, waits until it is ready and parses it.
+func (c *Client) GenerateRelatorio(ctx context.Context, r *Relatorio, interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	if err := c.CreateRelatorio(ctx, r); err != nil {
+		return nil, err
+	}
+
+	if err := c.WaitRelatorio(ctx, r, interval, timeout); err != nil {
+		return nil, err
+	}
+
+	return c.DownloadRelatorio(ctx, r)
+}
+
+// GenerateRelatorios generates one report per day from inicio to fim, both
+// included, and returns their entries in order. The timeout applies to each
+// report.
+func (c *Client) GenerateRelatorios(ctx context.Context, inicio, fim time.Time, tipos *TipoRegistros, interval, timeout time.Duration) ([]RelatorioLinha, error) {
+	inicio = time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, time.UTC)
+	fim = time.Date(fim.Year(), fim.Month(), fim.Day(), 0, 0, 0, 0, time.UTC)
+	if fim.Before(inicio) {
+		return nil, errors.New("fim must not be before inicio")
+	}
+
+	var linhas []RelatorioLinha
+	for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+		r := &Relatorio{DataMovimento: dia.Format(time.DateOnly), TipoRegistros: tipos}
+
+		day, err := c.GenerateRelatorio(ctx, r, interval, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("report of %s: %w", r.DataMovimento, err)
+		}
+		linhas = append(linhas, day...)
+	}
+
+	return linhas, nil
+}
+
+// Layout of the report CSV: semicolon separated, with a header naming the
+// columns below and timestamps in RFC 3339. Amounts use a dot as the decimal
+// separator.
+const (
+	relatorioSeparador = ';'
+	relatorioTipo      = "tipo"
+	relatorioE2EID     = "e2eId"
+	relatorioTxID      = "txid"
+	relatorioValor     = "valor"
+	relatorioTarifa    = "tarifa"
+	relatorioData      = "data"
+)
+
+// parseRelatorio parses a report CSV, locating the columns by their header
+func parseRelatorio(r io.Reader) ([]RelatorioLinha, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = relatorioSeparador
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("report is empty")
+	}
+
+	// Index the columns by name, tolerating a byte order mark before the header.
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	for _, name := range []string{relatorioE2EID, relatorioValor, relatorioData} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("report is missing the %s column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	linhas := make([]RelatorioLinha, 0, len(records)-1)
+	for n, record := range records[1:] {
+		line := n + 2
+
+		linha := RelatorioLinha{
+			Tipo:       field(record, relatorioTipo),
+			EndToEndID: field(record, relatorioE2EID),
+			TxID:       field(record, relatorioTxID),
+		}
+
+		if linha.Valor, err = parseCentavos(field(record, relatorioValor)); err != nil {
+			return nil, fmt.Errorf("report line %d: %v", line, err)
+		}
+
+		if tarifa := field(record, relatorioTarifa); tarifa != "" {
+			if linha.Tarifa, err = parseCentavos(tarifa); err != nil {
+				return nil, fmt.Errorf("report line %d: %v", line, err)
+			}
+		}
+
+		if linha.Data, err = time.Parse(time.RFC3339, field(record, relatorioData)); err != nil {
+			return nil, fmt.Errorf("report line %d: invalid data: %v", line, err)
+		}
+
+		linhas = append(linhas, linha)
+	}
+
+	return linhas, nil
+}
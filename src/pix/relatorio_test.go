@@ -0,0 +1,196 @@
+package pix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRelatorio(t *testing.T) {
+	report := "\ufefftipo;e2eId;txid;valor;tarifa;data\n" +
+		"PIX_RECEBIDO;E1;tx1;10.50;0.09;2026-10-01T10:00:00-03:00\n" +
+		"DEVOLUCAO;E2;;5;;2026-10-02T11:30:00Z\n"
+
+	linhas, err := parseRelatorio(strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("parseRelatorio: %v", err)
+	}
+
+	want := []RelatorioLinha{
+		{Tipo: "PIX_RECEBIDO", EndToEndID: "E1", TxID: "tx1", Valor: 1050, Tarifa: 9, Data: time.Date(2026, 10, 1, 13, 0, 0, 0, time.UTC)},
+		{Tipo: "DEVOLUCAO", EndToEndID: "E2", Valor: 500, Data: time.Date(2026, 10, 2, 11, 30, 0, 0, time.UTC)},
+	}
+	if len(linhas) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(linhas), len(want))
+	}
+	for i := range want {
+		got := linhas[i]
+		if got.Tipo != want[i].Tipo || got.EndToEndID != want[i].EndToEndID || got.TxID != want[i].TxID ||
+			got.Valor != want[i].Valor || got.Tarifa != want[i].Tarifa || !got.Data.Equal(want[i].Data) {
+			t.Errorf("row %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestParseRelatorioErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+		err    string
+	}{
+		{"empty", "", "report is empty"},
+		{"unknown header", "tipo;endToEnd;valor;data\nX;E1;1.00;2026-10-01T10:00:00Z\n", "missing the e2eId column"},
+		{"comma separated", "tipo,e2eId,txid,valor,tarifa,data\n", "missing the e2eId column"},
+		{"missing valor", "e2eId;data\nE1;2026-10-01T10:00:00Z\n", "missing the valor column"},
+		{"invalid valor", "e2eId;valor;data\nE1;1,50;2026-10-01T10:00:00Z\n", "report line 2"},
+		{"invalid data", "e2eId;valor;data\nE1;1.50;01/10/2026\n", "report line 2: invalid data"},
+	}
+
+	for _, tt := range tests {
+		_, err := parseRelatorio(strings.NewReader(tt.report))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: parseRelatorio = %v, want an error containing %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestWaitRelatorio(t *testing.T) {
+	var status atomic.Value
+	status.Store(RelatorioErro)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"r1","status":"` + status.Load().(string) + `"}`))
+	}))
+
+	r := &Relatorio{ID: "r1"}
+	if err := client.WaitRelatorio(context.Background(), r, 0, time.Second); err == nil {
+		t.Error("WaitRelatorio with a zero interval succeeded")
+	}
+	if err := client.WaitRelatorio(context.Background(), r, time.Millisecond, time.Second); err == nil {
+		t.Error("WaitRelatorio on a failed report succeeded")
+	}
+
+	status.Store(RelatorioConcluido)
+	if err := client.WaitRelatorio(context.Background(), r, time.Millisecond, time.Second); err != nil {
+		t.Errorf("WaitRelatorio: %v", err)
+	}
+}
+
+// newRelatorioClient serves reports that are processing on their first GET and
+// ready on the next one, recording the days requested.
+func newRelatorioClient(t *testing.T, days *[]string, gets *atomic.Int32) *Client {
+	t.Helper()
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth/token":
+			writeToken(w, "token", 3600)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/gn/relatorios/extrato-conciliacao":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			if got := keys(body); !slices.Equal(got, []string{"dataMovimento", "tipoRegistros"}) {
+				t.Errorf("body keys = %v, want dataMovimento and tipoRegistros", got)
+			}
+			day, _ := body["dataMovimento"].(string)
+
+			mu.Lock()
+			*days = append(*days, day)
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"r-` + day + `","dataMovimento":"` + day + `","status":"EM_PROCESSAMENTO"}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v2/gn/relatorios/r-"):
+			gets.Add(1)
+			id := strings.TrimPrefix(r.URL.Path, "/v2/gn/relatorios/")
+
+			mu.Lock()
+			ready := seen[id]
+			seen[id] = true
+			mu.Unlock()
+
+			if !ready {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"id":"` + id + `","status":"EM_PROCESSAMENTO"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("tipo;e2eId;txid;valor;tarifa;data\n" +
+				"PIX_RECEBIDO;E" + id + ";;1.00;;" + strings.TrimPrefix(id, "r-") + "T12:00:00Z\n"))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGenerateRelatorio(t *testing.T) {
+	var days []string
+	var gets atomic.Int32
+	client := newRelatorioClient(t, &days, &gets)
+
+	r := &Relatorio{DataMovimento: "2026-10-01", TipoRegistros: &TipoRegistros{PixRecebido: true}}
+	linhas, err := client.GenerateRelatorio(context.Background(), r, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("GenerateRelatorio: %v", err)
+	}
+
+	if r.ID != "r-2026-10-01" || r.Status != RelatorioConcluido {
+		t.Errorf("report = %s %s, want r-2026-10-01 %s", r.ID, r.Status, RelatorioConcluido)
+	}
+	if len(linhas) != 1 || linhas[0].EndToEndID != "Er-2026-10-01" || linhas[0].Valor != 100 {
+		t.Errorf("rows = %+v, want a single 1.00 entry", linhas)
+	}
+	// The file comes with the status once ready, so it is not fetched again.
+	if n := gets.Load(); n != 2 {
+		t.Errorf("sent %d GET requests, want 2", n)
+	}
+
+	if err := client.CreateRelatorio(context.Background(), &Relatorio{}); err == nil {
+		t.Error("CreateRelatorio without dataMovimento succeeded")
+	}
+}
+
+func TestGenerateRelatorios(t *testing.T) {
+	var days []string
+	var gets atomic.Int32
+	client := newRelatorioClient(t, &days, &gets)
+
+	inicio := time.Date(2026, 9, 30, 15, 0, 0, 0, time.UTC)
+	fim := time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)
+	linhas, err := client.GenerateRelatorios(context.Background(), inicio, fim, &TipoRegistros{PixRecebido: true}, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("GenerateRelatorios: %v", err)
+	}
+
+	want := []string{"2026-09-30", "2026-10-01", "2026-10-02"}
+	if !slices.Equal(days, want) {
+		t.Errorf("requested days %v, want %v", days, want)
+	}
+	if len(linhas) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(linhas), len(want))
+	}
+	for i, day := range want {
+		if got := linhas[i].Data.Format(time.DateOnly); got != day {
+			t.Errorf("row %d is from %s, want %s", i, got, day)
+		}
+	}
+
+	if _, err := client.GenerateRelatorios(context.Background(), fim, inicio, nil, time.Millisecond, time.Second); err == nil {
+		t.Error("GenerateRelatorios with fim before inicio succeeded")
+	}
+}
@@ -0,0 +1,128 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how transient failures are retried.
+// Only GET and PUT requests are retried: PUT always carries a caller-supplied
+// identifier such as the txid, so replaying it cannot create a second charge.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts, including the first one; 0 or 1 disables retries
+	MinBackoff  time.Duration // Base delay before the first retry, 200ms if unset
+	MaxBackoff  time.Duration // Upper bound for the delay between retries, Retry-After included, 5s if unset
+}
+
+// attempts returns the total number of attempts allowed by the policy
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns how long to wait before the given retry, honoring Retry-After
+// up to MaxBackoff
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = 200 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+
+	// A long Retry-After must not hold the caller beyond the policy's bound.
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, maxBackoff)
+		}
+	}
+
+	// Exponential backoff with jitter over the upper half of the interval.
+	wait := minBackoff << (attempt - 1)
+	if wait <= 0 || wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// retryable reports whether a failed attempt can safely be sent again
+func retryable(req *http.Request, res *http.Response, err error) bool {
+	// Never replay requests that may have created a resource on the server.
+	if req.Method != http.MethodGet && req.Method != http.MethodPut {
+		return false
+	}
+
+	// Requests with a body can only be replayed if it can be rebuilt.
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout() ||
+			errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF)
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// rewind prepares the request to be sent again
+func rewind(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+
+	return nil
+}
+
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
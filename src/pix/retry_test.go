@@ -0,0 +1,130 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	// Exponential growth with jitter over the upper half, capped at MaxBackoff.
+	for attempt, ceiling := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		10: time.Second,
+		80: time.Second,
+	} {
+		for range 50 {
+			wait := p.backoff(attempt, nil)
+			if wait < ceiling/2 || wait > ceiling {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", attempt, wait, ceiling/2, ceiling)
+			}
+		}
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "0")
+	if wait := p.backoff(1, res); wait != 0 {
+		t.Errorf("backoff with Retry-After: 0 = %v, want 0", wait)
+	}
+
+	res.Header.Set("Retry-After", "3600")
+	if wait := p.backoff(1, res); wait != time.Second {
+		t.Errorf("backoff with Retry-After: 3600 = %v, want MaxBackoff", wait)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	post, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	status := func(code int) *http.Response { return &http.Response{StatusCode: code} }
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		res  *http.Response
+		err  error
+		want bool
+	}{
+		{"server error", get, status(http.StatusBadGateway), nil, true},
+		{"rate limited", get, status(http.StatusTooManyRequests), nil, true},
+		{"client error", get, status(http.StatusBadRequest), nil, false},
+		{"connection reset", get, nil, syscall.ECONNRESET, true},
+		{"unexpected eof", get, nil, io.ErrUnexpectedEOF, true},
+		{"other error", get, nil, errors.New("boom"), false},
+		{"post", post, status(http.StatusServiceUnavailable), nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := retryable(tt.req, tt.res, tt.err); got != tt.want {
+			t.Errorf("%s: retryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteRetries(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			writeToken(w, "token", 3600)
+			return
+		}
+
+		// Fail the first two attempts of every request.
+		if calls.Add(1)%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"txid":"abc","status":"ATIVA"}`))
+	}), func(c *Credentials) {
+		c.Retry = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	})
+
+	p := &Pix{TxID: "abc"}
+	if err := client.FetchPix(context.Background(), p); err != nil {
+		t.Fatalf("FetchPix: %v", err)
+	}
+	if p.Status != StatusAtiva || calls.Load() != 3 {
+		t.Errorf("status %q after %d attempts, want %q after 3", p.Status, calls.Load(), StatusAtiva)
+	}
+
+	// POST may have created the charge, so it is never replayed.
+	calls.Store(0)
+	err := client.CreatePix(context.Background(), &Pix{Chave: "key"})
+	if !hasStatus(err, http.StatusServiceUnavailable) || calls.Load() != 1 {
+		t.Errorf("CreatePix = %v after %d attempts, want a 503 after 1", err, calls.Load())
+	}
+}
@@ -0,0 +1,57 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Saldo represents the balance of the account.
+type Saldo struct {
+	Saldo     string     `json:"saldo,omitempty"`     // Available balance
+	Bloqueios *Bloqueios `json:"bloqueios,omitempty"` // Blocked amounts, when requested
+	BadRequest
+}
+
+// Bloqueios holds the amounts blocked in the account.
+type Bloqueios struct {
+	Judicial       string `json:"judicial,omitempty"`       // Blocked by court order
+	MedidaCautelar string `json:"medidaCautelar,omitempty"` // Blocked as a precautionary measure
+	Total          string `json:"total,omitempty"`          // Total blocked
+}
+
+// Centavos returns the available balance in cents.
+func (s *Saldo) Centavos() (int64, error) {
+	return parseCentavos(s.Saldo)
+}
+
+// Fetch retrieves the account balance using the default client.
+// When bloqueios is true the blocked amounts are returned as well.
+func (s *Saldo) Fetch(bloqueios bool) error {
+	return s.FetchContext(context.Background(), bloqueios)
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (s *Saldo) FetchContext(ctx context.Context, bloqueios bool) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchSaldo(ctx, s, bloqueios)
+}
+
+// FetchSaldo retrieves the account balance and, when bloqueios is true, the blocked amounts.
+func (c *Client) FetchSaldo(ctx context.Context, s *Saldo, bloqueios bool) error {
+	// Create a new HTTP GET request for fetching the balance.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "saldo")
+	if err != nil {
+		return err
+	}
+
+	if bloqueios {
+		req.URL.RawQuery = url.Values{"bloqueios": {"true"}}.Encode()
+	}
+
+	// Execute the request and unmarshal the response into the Saldo object.
+	return c.do(req, s, http.StatusOK)
+}
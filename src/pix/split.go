@@ -0,0 +1,356 @@
+package pix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Types of a split part.
+const (
+	SplitPorcentagem = "porcentagem" // Valor is a percentage of the charge
+	SplitFixo        = "fixo"        // Valor is a fixed amount
+)
+
+// How the Efí fee is divided between the parts.
+const (
+	DivisaoTarifaAssumirTotal = "assumir_total" // The account owner pays the whole fee
+	DivisaoTarifaProporcional = "proporcional"  // Every part pays in proportion to its share
+)
+
+// SplitConfig represents how the payment of a charge is divided between accounts.
+type SplitConfig struct {
+	ID         string           `json:"id,omitempty"`         // Split configuration ID
+	TxID       string           `json:"txid,omitempty"`       // Charge the configuration was created for
+	Status     string           `json:"status,omitempty"`     // Configuration status
+	Descricao  string           `json:"descricao,omitempty"`  // Description of the configuration
+	Lancamento *SplitLancamento `json:"lancamento,omitempty"` // When the parts are credited
+	Split      *Split           `json:"split,omitempty"`      // How the amount is divided
+	BadRequest
+}
+
+// SplitLancamento represents when the split parts are credited.
+type SplitLancamento struct {
+	Imediato bool `json:"imediato"` // Whether the parts are credited as soon as the charge is paid
+}
+
+// Split represents the parts the amount of a charge is divided into.
+type Split struct {
+	DivisaoTarifa string     `json:"divisaoTarifa,omitempty"` // How the fee is divided
+	MinhaParte    *SplitPart `json:"minhaParte,omitempty"`    // Part kept by the account owner
+	Repasses      []Repasse  `json:"repasses,omitempty"`      // Parts sent to other accounts
+}
+
+// SplitPart represents a single part of a split.
+type SplitPart struct {
+	Tipo  string `json:"tipo,omitempty"`  // Part type
+	Valor string `json:"valor,omitempty"` // Percentage or amount, depending on Tipo
+}
+
+// Repasse represents a part sent to another Efí account.
+type Repasse struct {
+	Tipo       string           `json:"tipo,omitempty"`       // Part type
+	Valor      string           `json:"valor,omitempty"`      // Percentage or amount, depending on Tipo
+	Favorecido *SplitFavorecido `json:"favorecido,omitempty"` // Account receiving the part
+}
+
+// SplitFavorecido represents the Efí account receiving a part.
+type SplitFavorecido struct {
+	CPF   string `json:"cpf,omitempty"`   // Account holder CPF
+	CNPJ  string `json:"cnpj,omitempty"`  // Account holder CNPJ
+	Conta string `json:"conta,omitempty"` // Efí account number
+}
+
+// Validate checks that the parts are well formed and add up correctly:
+// percentages must total 100 and fixed parts must total valorCob, the
+// amount of the charge the configuration applies to. valorCob may be empty
+// while the charge is not known yet, in which case fixed totals are checked
+// when the configuration is linked.
+func (s *SplitConfig) Validate(valorCob string) error {
+	if s.Split == nil || s.Split.MinhaParte == nil {
+		return errors.New("split.minhaParte is required")
+	}
+	if len(s.Split.Repasses) == 0 {
+		return errors.New("split.repasses is required")
+	}
+
+	// Every part must use the same type as the account owner's part.
+	tipo := s.Split.MinhaParte.Tipo
+	if tipo != SplitPorcentagem && tipo != SplitFixo {
+		return fmt.Errorf("invalid split tipo %q", tipo)
+	}
+
+	total, err := splitPartValue(tipo, *s.Split.MinhaParte)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range s.Split.Repasses {
+		value, err := splitPartValue(tipo, SplitPart{Tipo: r.Tipo, Valor: r.Valor})
+		if err != nil {
+			return err
+		}
+		total += value
+
+		if r.Favorecido == nil || r.Favorecido.Conta == "" {
+			return errors.New("repasse favorecido.conta is required")
+		}
+		if (r.Favorecido.CPF == "") == (r.Favorecido.CNPJ == "") {
+			return errors.New("repasse favorecido requires either cpf or cnpj")
+		}
+	}
+
+	// Percentages are parsed with two decimals, so 100% is 10000.
+	if tipo == SplitPorcentagem && total != 10000 {
+		return fmt.Errorf("split percentages add up to %d.%02d, want 100.00", total/100, total%100)
+	}
+
+	if tipo == SplitFixo && valorCob != "" {
+		want, err := parseCentavos(valorCob)
+		if err != nil {
+			return err
+		}
+		if total != want {
+			return fmt.Errorf("split fixed parts add up to %d.%02d, want %s", total/100, total%100, valorCob)
+		}
+	}
+
+	return nil
+}
+
+// splitPartValue parses the value of a part, checking it has the expected type
+func splitPartValue(tipo string, p SplitPart) (int64, error) {
+	if p.Tipo != tipo {
+		return 0, fmt.Errorf("split parts must all be %s, got %q", tipo, p.Tipo)
+	}
+
+	value, err := parseCentavos(p.Valor)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, fmt.Errorf("split part valor must be positive, got %q", p.Valor)
+	}
+
+	return value, nil
+}
+
+// Create registers the split configuration using the default client.
+func (s *SplitConfig) Create() error {
+	return s.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but carries ctx through the request.
+func (s *SplitConfig) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateSplitConfig(ctx, s)
+}
+
+// Update replaces the split configuration using the default client.
+func (s *SplitConfig) Update() error {
+	return s.UpdateContext(context.Background())
+}
+
+// UpdateContext is like Update but carries ctx through the request.
+func (s *SplitConfig) UpdateContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UpdateSplitConfig(ctx, s)
+}
+
+// Fetch retrieves the split configuration using the default client.
+func (s *SplitConfig) Fetch() error {
+	return s.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but carries ctx through the request.
+func (s *SplitConfig) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.FetchSplitConfig(ctx, s)
+}
+
+// Link applies the split configuration to a charge using the default client.
+// tipoCob is TipoCobCob or TipoCobCobV.
+func (s *SplitConfig) Link(tipoCob, txid string) error {
+	return s.LinkContext(context.Background(), tipoCob, txid)
+}
+
+// LinkContext is like Link but carries ctx through the request.
+func (s *SplitConfig) LinkContext(ctx context.Context, tipoCob, txid string) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.LinkSplitConfig(ctx, s, tipoCob, txid)
+}
+
+// Unlink removes the split configuration from a charge using the default client.
+func (s *SplitConfig) Unlink(tipoCob, txid string) error {
+	return s.UnlinkContext(context.Background(), tipoCob, txid)
+}
+
+// UnlinkContext is like Unlink but carries ctx through the request.
+func (s *SplitConfig) UnlinkContext(ctx context.Context, tipoCob, txid string) error {
+	client, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return client.UnlinkSplitConfig(ctx, s, tipoCob, txid)
+}
+
+// CreateSplitConfig validates and registers a new split configuration; the API assigns its ID.
+func (c *Client) CreateSplitConfig(ctx context.Context, s *SplitConfig) error {
+	// Check the parts locally before sending them; fixed totals are checked on link.
+	if err := s.Validate(""); err != nil {
+		return err
+	}
+
+	// Create a new HTTP POST request with the configuration as the body.
+	req, err := c.newRequest(ctx, http.MethodPost, s.body(), "v2", "gn", "split", "config")
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SplitConfig object.
+	return c.do(req, s, http.StatusCreated)
+}
+
+// UpdateSplitConfig validates and replaces the split configuration with the given ID.
+func (c *Client) UpdateSplitConfig(ctx context.Context, s *SplitConfig) error {
+	// Ensure that the ID is provided; it is required to replace the configuration.
+	if s.ID == "" {
+		return errors.New("id is required")
+	}
+
+	// Check the parts locally before sending them; fixed totals are checked on link.
+	if err := s.Validate(""); err != nil {
+		return err
+	}
+
+	// Create a new HTTP PUT request with the configuration as the body.
+	req, err := c.newRequest(ctx, http.MethodPut, s.body(), "v2", "gn", "split", "config", s.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SplitConfig object.
+	return c.do(req, s, http.StatusCreated)
+}
+
+// FetchSplitConfig retrieves a split configuration using its ID.
+func (c *Client) FetchSplitConfig(ctx context.Context, s *SplitConfig) error {
+	// Ensure that the ID is provided; it is required to fetch the configuration.
+	if s.ID == "" {
+		return errors.New("id is required")
+	}
+
+	// Create a new HTTP GET request for fetching the configuration.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "gn", "split", "config", s.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request and unmarshal the response into the SplitConfig object.
+	return c.do(req, s, http.StatusOK)
+}
+
+// LinkSplitConfig applies the split configuration to the charge of the given type and TxID.
+// Fixed parts are checked against the amount of the charge before linking.
+func (c *Client) LinkSplitConfig(ctx context.Context, s *SplitConfig, tipoCob, txid string) error {
+	// Load the parts if the caller only has the configuration ID.
+	if s.Split == nil && s.ID != "" {
+		if err := c.FetchSplitConfig(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	if s.Split != nil && s.Split.MinhaParte != nil && s.Split.MinhaParte.Tipo == SplitFixo {
+		valorCob, err := c.chargeAmount(ctx, tipoCob, txid)
+		if err != nil {
+			return err
+		}
+		if err := s.Validate(valorCob); err != nil {
+			return err
+		}
+	}
+
+	return c.splitLink(ctx, http.MethodPut, s, tipoCob, txid)
+}
+
+// UnlinkSplitConfig removes the split configuration from the charge of the given type and TxID.
+func (c *Client) UnlinkSplitConfig(ctx context.Context, s *SplitConfig, tipoCob, txid string) error {
+	return c.splitLink(ctx, http.MethodDelete, s, tipoCob, txid)
+}
+
+// splitLink sends a request to the link between a charge and a split configuration
+func (c *Client) splitLink(ctx context.Context, method string, s *SplitConfig, tipoCob, txid string) error {
+	// Ensure that the configuration and the charge are identified.
+	if s.ID == "" {
+		return errors.New("id is required")
+	}
+	if txid == "" {
+		return errors.New("txid is required")
+	}
+	if tipoCob != TipoCobCob && tipoCob != TipoCobCobV {
+		return fmt.Errorf("invalid tipoCob %q", tipoCob)
+	}
+
+	// Create a new HTTP request for the link.
+	req, err := c.newRequest(ctx, method, nil, "v2", "gn", "split", tipoCob, txid, "vinculo", s.ID)
+	if err != nil {
+		return err
+	}
+
+	// Execute the request; a successful response has no body.
+	return c.do(req, nil, http.StatusNoContent)
+}
+
+// chargeAmount returns the original amount of the charge of the given type and TxID
+func (c *Client) chargeAmount(ctx context.Context, tipoCob, txid string) (string, error) {
+	switch tipoCob {
+	case TipoCobCob:
+		p := &Pix{TxID: txid}
+		if err := c.FetchPix(ctx, p); err != nil {
+			return "", err
+		}
+
+		// Pix.Valor holds the decoded JSON object, so read it back as a Valor.
+		data, err := json.Marshal(p.Valor)
+		if err != nil {
+			return "", err
+		}
+		var valor Valor
+		if err := json.Unmarshal(data, &valor); err != nil {
+			return "", err
+		}
+		return valor.Original, nil
+
+	case TipoCobCobV:
+		cv := &CobV{TxID: txid}
+		if err := c.FetchCobV(ctx, cv); err != nil {
+			return "", err
+		}
+		if cv.Valor == nil {
+			return "", nil
+		}
+		return cv.Valor.Original, nil
+
+	default:
+		return "", fmt.Errorf("invalid tipoCob %q", tipoCob)
+	}
+}
+
+// body returns a copy of the configuration without the fields set by the API
+func (s *SplitConfig) body() SplitConfig {
+	return SplitConfig{Descricao: s.Descricao, Lancamento: s.Lancamento, Split: s.Split}
+}
@@ -0,0 +1,92 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestSplit returns a split configuration with the given type and values,
+// the first one kept by the account owner
+func newTestSplit(tipo string, valores ...string) *SplitConfig {
+	split := &Split{MinhaParte: &SplitPart{Tipo: tipo, Valor: valores[0]}}
+	for _, valor := range valores[1:] {
+		split.Repasses = append(split.Repasses, Repasse{
+			Tipo:       tipo,
+			Valor:      valor,
+			Favorecido: &SplitFavorecido{CPF: "12345678909", Conta: "1234567"},
+		})
+	}
+	return &SplitConfig{ID: "split1", Split: split}
+}
+
+func TestSplitConfigValidate(t *testing.T) {
+	mixed := newTestSplit(SplitPorcentagem, "50.00", "50.00")
+	mixed.Split.Repasses[0].Tipo = SplitFixo
+
+	noConta := newTestSplit(SplitPorcentagem, "50.00", "50.00")
+	noConta.Split.Repasses[0].Favorecido.Conta = ""
+
+	tests := []struct {
+		name     string
+		config   *SplitConfig
+		valorCob string
+		err      string
+	}{
+		{"percentages", newTestSplit(SplitPorcentagem, "60.00", "15", "25.00"), "", ""},
+		{"percentages short", newTestSplit(SplitPorcentagem, "60.00", "14.99", "25.00"), "", "add up to 99.99"},
+		{"percentages over", newTestSplit(SplitPorcentagem, "60.00", "50.00"), "", "add up to 110.00"},
+		{"fixed without charge", newTestSplit(SplitFixo, "7.00", "900.00"), "", ""},
+		{"fixed matching charge", newTestSplit(SplitFixo, "70.00", "30.00"), "100.00", ""},
+		{"fixed over charge", newTestSplit(SplitFixo, "70.00", "40.00"), "100.00", "add up to 110.00"},
+		{"fixed under charge", newTestSplit(SplitFixo, "70.00", "20.00"), "100", "add up to 90.00"},
+		{"zero part", newTestSplit(SplitFixo, "100.00", "0.00"), "100.00", "must be positive"},
+		{"invalid amount", newTestSplit(SplitFixo, "100,00", "1.00"), "", "invalid amount"},
+		{"mixed types", mixed, "", "must all be porcentagem"},
+		{"unknown type", newTestSplit("metade", "50", "50"), "", "invalid split tipo"},
+		{"missing conta", noConta, "", "favorecido.conta is required"},
+		{"no repasses", newTestSplit(SplitPorcentagem, "100.00"), "", "split.repasses is required"},
+		{"no split", &SplitConfig{}, "", "split.minhaParte is required"},
+	}
+
+	for _, tt := range tests {
+		err := tt.config.Validate(tt.valorCob)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: Validate = %v, want nil", tt.name, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s: Validate = %v, want an error containing %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestLinkSplitConfigChecksFixedTotal(t *testing.T) {
+	var linked atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth/token":
+			writeToken(w, "token", 3600)
+		case r.URL.Path == "/v2/cob/abc":
+			w.Write([]byte(`{"txid":"abc","valor":{"original":"100.00"}}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/gn/split/cob/abc/vinculo/"):
+			linked.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	err := client.LinkSplitConfig(context.Background(), newTestSplit(SplitFixo, "70.00", "40.00"), TipoCobCob, "abc")
+	if err == nil || linked.Load() != 0 {
+		t.Fatalf("linking parts over the charge = %v with %d links, want an error and no link", err, linked.Load())
+	}
+
+	if err := client.LinkSplitConfig(context.Background(), newTestSplit(SplitFixo, "70.00", "30.00"), TipoCobCob, "abc"); err != nil {
+		t.Fatalf("LinkSplitConfig: %v", err)
+	}
+	if n := linked.Load(); n != 1 {
+		t.Errorf("made %d links, want 1", n)
+	}
+}
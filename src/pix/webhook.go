@@ -1,19 +1,29 @@
 package pix
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
+	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Kinds of webhook registration.
+//
+// Sent PIX and refund notifications have no registration of their own: they
+// are delivered to the WebhookPix registration of the key that sent or
+// refunded the payment, once enabled in the account configuration.
+const (
+	WebhookPix  = ""     // Charges and PIX of a key, registered under /v2/webhook/:chave
+	WebhookRec  = "rec"  // Pix Automático recurrences, registered under /v2/webhookrec
+	WebhookCobR = "cobr" // Pix Automático recurring charges, registered under /v2/webhookcobr
+)
+
 // Webhook represents the structure for managing PIX webhooks.
 type Webhook struct {
+	Tipo       string      `json:"-"`                    // Kind of registration, WebhookPix by default
 	WebhookURL string      `json:"webhookUrl,omitempty"` // The URL where the webhook will send notifications
 	Chave      string      `json:"chave,omitempty"`      // The key associated with the webhook
 	SkipMTLS   bool        `json:"-"`                    // Option to skip mutual TLS check
@@ -24,146 +34,204 @@ type Webhook struct {
 	BadRequest             // Embedding for error handling
 }
 
-// Create registers a new webhook for a PIX key.
+// Create registers a new webhook using the default client.
 func (w *Webhook) Create() error {
-	// Obtain an OAuth token for authentication.
-	token := OAuth()
-	if token.Error != nil {
-		return token.Error
-	}
+	return w.CreateContext(context.Background())
+}
 
-	// Load the client certificate for secure communication.
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
+// CreateContext is like Create but carries ctx through the request.
+func (w *Webhook) CreateContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.CreateWebhook(ctx, w)
+}
 
-	// Set up the HTTP client with a timeout and TLS configuration.
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
-	}
+// Delete removes an existing webhook using the default client.
+func (w *Webhook) Delete() error {
+	return w.DeleteContext(context.Background())
+}
 
-	// Construct the request path for creating the webhook.
-	path, err := url.JoinPath(EFI_BASE_URL, "v2", "webhook", w.Chave)
+// DeleteContext is like Delete but carries ctx through the request.
+func (w *Webhook) DeleteContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.DeleteWebhook(ctx, w)
+}
 
-	// Clear the Chave field in the Webhook structure to avoid sending it.
-	w.Chave = ""
+// Fetch retrieves the registered webhook using the default client.
+func (w *Webhook) Fetch() error {
+	return w.FetchContext(context.Background())
+}
 
-	// Marshal the Webhook structure to JSON.
-	data, err := json.Marshal(w)
+// FetchContext is like Fetch but carries ctx through the request.
+func (w *Webhook) FetchContext(ctx context.Context) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.FetchWebhook(ctx, w)
+}
 
-	// Create a new HTTP PUT request to register the webhook.
-	req, err := http.NewRequest(http.MethodPut, path, bytes.NewBuffer(data))
+// Check verifies that the webhook is registered for the expected URL using the default client.
+func (w *Webhook) Check(expected string) error {
+	return w.CheckContext(context.Background(), expected)
+}
+
+// CheckContext is like Check but carries ctx through the request.
+func (w *Webhook) CheckContext(ctx context.Context, expected string) error {
+	client, err := defaultClient()
 	if err != nil {
 		return err
 	}
+	return client.CheckWebhook(ctx, w, expected)
+}
 
-	// Set the appropriate headers for the request.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("authorization", authorization())
-	req.Header.Set("x-skip-mtls-checking", strconv.FormatBool(w.SkipMTLS))
+// ListWebhooks lists the key webhooks registered within the period using the default client.
+func ListWebhooks(inicio, fim time.Time, paginacao Paginacao) (*Webhook, error) {
+	return ListWebhooksContext(context.Background(), inicio, fim, paginacao)
+}
 
-	// Execute the HTTP request.
-	res, err := client.Do(req)
+// ListWebhooksContext is like ListWebhooks but carries ctx through the request.
+func ListWebhooksContext(ctx context.Context, inicio, fim time.Time, paginacao Paginacao) (*Webhook, error) {
+	client, err := defaultClient()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return client.ListWebhooks(ctx, inicio, fim, paginacao)
+}
 
-	// Read the response body.
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return err
+// Matches reports whether the webhook is registered for the given URL,
+// ignoring a trailing slash.
+func (w *Webhook) Matches(url string) bool {
+	return strings.TrimSuffix(w.WebhookURL, "/") == strings.TrimSuffix(url, "/")
+}
+
+// path returns the API path of the webhook registration.
+func (w *Webhook) path() ([]string, error) {
+	switch w.Tipo {
+	case WebhookPix:
+		if w.Chave == "" {
+			return nil, errors.New("chave is required")
+		}
+		return []string{"v2", "webhook", w.Chave}, nil
+	case WebhookRec:
+		return []string{"v2", "webhookrec"}, nil
+	case WebhookCobR:
+		return []string{"v2", "webhookcobr"}, nil
+	default:
+		return nil, fmt.Errorf("unknown webhook type %s", w.Tipo)
 	}
-	defer res.Body.Close()
+}
 
-	// Unmarshal the response body into the Webhook structure.
-	if err := json.Unmarshal(body, &w); err != nil {
+// CreateWebhook registers a new webhook of the kind set in Tipo.
+func (c *Client) CreateWebhook(ctx context.Context, w *Webhook) error {
+	path, err := w.path()
+	if err != nil {
 		return err
 	}
 
-	// Check if the request was successful.
-	if res.StatusCode != http.StatusCreated {
-		return errors.New("bad request")
+	// Send only the URL; the key is already part of the path.
+	body := Webhook{WebhookURL: w.WebhookURL}
+
+	// Create a new HTTP PUT request to register the webhook.
+	req, err := c.newRequest(ctx, http.MethodPut, body, path...)
+	if err != nil {
+		return err
 	}
+	req.Header.Set("x-skip-mtls-checking", strconv.FormatBool(w.SkipMTLS))
 
-	return nil // Return nil if the webhook was successfully created.
+	// Execute the request and unmarshal the response into the Webhook structure.
+	return c.do(req, w, http.StatusCreated)
 }
 
-// Delete removes an existing webhook for a PIX key.
-func (w *Webhook) Delete() error {
-	// Obtain an OAuth token for authentication.
-	token := OAuth()
-	if token.Error != nil {
-		return token.Error
+// DeleteWebhook removes an existing webhook of the kind set in Tipo.
+func (c *Client) DeleteWebhook(ctx context.Context, w *Webhook) error {
+	path, err := w.path()
+	if err != nil {
+		return err
 	}
 
-	// Load the client certificate for secure communication.
-	cert, err := tls.LoadX509KeyPair(Client.CA, Client.Key)
+	// Create a new HTTP DELETE request to remove the webhook.
+	req, err := c.newRequest(ctx, http.MethodDelete, nil, path...)
 	if err != nil {
 		return err
 	}
 
-	// Set up the HTTP client with a timeout and TLS configuration.
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(Client.Timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-			},
-		},
-	}
+	// Execute the request; a successful deletion has no content.
+	return c.do(req, w, http.StatusNoContent)
+}
 
-	// Construct the request path for deleting the webhook.
-	path, err := url.JoinPath(EFI_BASE_URL, "v2", "webhook", w.Chave)
+// FetchWebhook retrieves the webhook of the kind set in Tipo.
+func (c *Client) FetchWebhook(ctx context.Context, w *Webhook) error {
+	path, err := w.path()
 	if err != nil {
 		return err
 	}
 
-	// Create a new HTTP DELETE request to remove the webhook.
-	req, err := http.NewRequest(http.MethodDelete, path, nil)
+	// Create a new HTTP GET request for fetching the webhook.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, path...)
 	if err != nil {
 		return err
 	}
 
-	// Set the appropriate headers for the request.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("authorization", authorization())
+	// Execute the request and unmarshal the response into the Webhook structure.
+	return c.do(req, w, http.StatusOK)
+}
 
-	// Execute the HTTP request.
-	res, err := client.Do(req)
-	if err != nil {
+// CheckWebhook fetches the webhook registered for a PIX key and verifies
+// that it points to the expected URL.
+func (c *Client) CheckWebhook(ctx context.Context, w *Webhook, expected string) error {
+	if err := c.FetchWebhook(ctx, w); err != nil {
+		if IsNotFound(err) {
+			return fmt.Errorf("no webhook registered for %s", w.describe())
+		}
 		return err
 	}
 
-	// Read the response body.
-	body, err := io.ReadAll(res.Body)
+	if !w.Matches(expected) {
+		return fmt.Errorf("webhook for %s points to %s instead of %s", w.describe(), w.WebhookURL, expected)
+	}
+
+	return nil
+}
+
+// describe names the registration in error messages.
+func (w *Webhook) describe() string {
+	if w.Tipo == WebhookPix {
+		return "pix key " + w.Chave
+	}
+	return "webhook" + w.Tipo
+}
+
+// ListWebhooks lists the key webhooks registered within the period, filling
+// Parametros and Webhooks of the returned structure. WebhookRec and
+// WebhookCobR have a single registration per account, retrieved with FetchWebhook.
+func (c *Client) ListWebhooks(ctx context.Context, inicio, fim time.Time, paginacao Paginacao) (*Webhook, error) {
+	// Build the query string from the period and pagination.
+	query, err := periodQuery(inicio, fim)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer res.Body.Close()
+	paginacao.setQuery(query)
 
-	// Unmarshal the response body and check the response status.
-	if err := json.Unmarshal(body, &w); err != nil && res.StatusCode != http.StatusNoContent {
-		return err
+	// Create a new HTTP GET request for listing webhooks.
+	req, err := c.newRequest(ctx, http.MethodGet, nil, "v2", "webhook")
+	if err != nil {
+		return nil, err
 	}
+	req.URL.RawQuery = query.Encode()
 
-	// Check if the request was successful.
-	if res.StatusCode != http.StatusNoContent {
-		return errors.New("bad request")
+	// Execute the request and unmarshal the response into the Webhook structure.
+	list := &Webhook{}
+	if err := c.do(req, list, http.StatusOK); err != nil {
+		return list, err
 	}
 
-	return nil // Return nil if the webhook was successfully deleted.
+	return list, nil
 }
 
 // Parametros defines the parameters for filtering webhook events.
@@ -0,0 +1,23 @@
+package pix
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateWebhookKeepsChave(t *testing.T) {
+	w := &Webhook{Chave: "key", WebhookURL: "https://example.com/pix"}
+
+	body := sentBody(t, http.StatusCreated, `{"webhookUrl":"https://example.com/pix"}`, func(c *Client) error {
+		return c.CreateWebhook(context.Background(), w)
+	})
+
+	if got := keys(body); !reflect.DeepEqual(got, []string{"webhookUrl"}) {
+		t.Errorf("sent fields %v, want [webhookUrl]", got)
+	}
+	if w.Chave != "key" {
+		t.Errorf("Chave = %q after Create, want it kept for Check", w.Chave)
+	}
+}